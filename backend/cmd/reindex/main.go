@@ -0,0 +1,62 @@
+// Command reindex walks every persisted TextAnalysis and pushes it to the
+// configured search.Index, for backfilling Elasticsearch after enabling it
+// or recovering from an index that's fallen out of sync with Postgres.
+package main
+
+import (
+	"context"
+	"log"
+
+	"github.com/bartosz/stocks-out-for-harambe/backend/internal/config"
+	"github.com/bartosz/stocks-out-for-harambe/backend/internal/models"
+	"github.com/bartosz/stocks-out-for-harambe/backend/internal/search"
+	"github.com/bartosz/stocks-out-for-harambe/backend/internal/storage"
+	"github.com/joho/godotenv"
+)
+
+// reindexBatchSize bounds how many analyses are loaded and bulk-indexed per
+// round trip.
+const reindexBatchSize = 500
+
+func main() {
+	if err := godotenv.Load(); err != nil {
+		log.Println("No .env file found, using system environment variables")
+	}
+
+	cfg := config.New()
+
+	db, err := storage.NewDatabase(cfg.Database)
+	if err != nil {
+		log.Fatalf("Failed to connect to database: %v", err)
+	}
+	defer db.Close()
+
+	index := search.BuildIndex(cfg.Search, db)
+
+	ctx := context.Background()
+	var total, offset int
+	for {
+		var batch []models.TextAnalysis
+		err := db.Preload("Keywords").Preload("Entities").
+			Order("id ASC").
+			Offset(offset).
+			Limit(reindexBatchSize).
+			Find(&batch).Error
+		if err != nil {
+			log.Fatalf("Failed to load analyses at offset %d: %v", offset, err)
+		}
+		if len(batch) == 0 {
+			break
+		}
+
+		if err := index.BulkIndex(ctx, batch); err != nil {
+			log.Fatalf("Failed to index batch at offset %d: %v", offset, err)
+		}
+
+		total += len(batch)
+		offset += reindexBatchSize
+		log.Printf("Reindexed %d analyses so far", total)
+	}
+
+	log.Printf("Reindex complete: %d analyses indexed", total)
+}
@@ -1,10 +1,13 @@
 package main
 
 import (
+	"context"
 	"log"
+	"os"
 
 	"github.com/bartosz/stocks-out-for-harambe/backend/internal/api"
 	"github.com/bartosz/stocks-out-for-harambe/backend/internal/config"
+	"github.com/bartosz/stocks-out-for-harambe/backend/internal/observability"
 	"github.com/bartosz/stocks-out-for-harambe/backend/internal/storage"
 	"github.com/gin-gonic/gin"
 	"github.com/joho/godotenv"
@@ -19,9 +22,23 @@ func main() {
 	// Initialize configuration
 	cfg := config.New()
 
+	// `server migrate <subcommand>` dispatches to storage's migration
+	// controls and exits, instead of starting the API server.
+	if len(os.Args) > 1 && os.Args[1] == "migrate" {
+		runMigrateCommand(cfg, os.Args[2:])
+	}
+
 	// Set Gin mode
 	gin.SetMode(cfg.GinMode)
 
+	// Start trace export (a no-op if OTEL_EXPORTER_OTLP_ENDPOINT is unset).
+	shutdownTracer, err := observability.InitTracer(context.Background(), cfg.Observability)
+	if err != nil {
+		log.Printf("Warning: failed to initialize tracing, continuing without trace export: %v", err)
+		shutdownTracer = func(context.Context) error { return nil }
+	}
+	defer shutdownTracer(context.Background())
+
 	// Initialize database
 	db, err := storage.NewDatabase(cfg.Database)
 	if err != nil {
@@ -0,0 +1,70 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"strconv"
+
+	"github.com/bartosz/stocks-out-for-harambe/backend/internal/config"
+	"github.com/bartosz/stocks-out-for-harambe/backend/internal/storage"
+)
+
+// runMigrateCommand dispatches `server migrate <subcommand> [args...]` to
+// the storage package's migration controls, so operators can roll back,
+// pin to a version, inspect state, or clear a dirty flag without reaching
+// for a separate golang-migrate binary (which wouldn't see migrationFS
+// anyway, since the SQL files are embedded rather than on disk).
+func runMigrateCommand(cfg *config.Config, args []string) {
+	if len(args) == 0 {
+		log.Fatal("usage: server migrate <up|down|to|version|force> [args...]")
+	}
+
+	var err error
+	switch args[0] {
+	case "up":
+		err = storage.RunMigrations(cfg.Database)
+	case "down":
+		steps := 1
+		if len(args) > 1 {
+			steps, err = strconv.Atoi(args[1])
+			if err != nil {
+				log.Fatalf("invalid steps %q: %v", args[1], err)
+			}
+		}
+		err = storage.MigrateDown(cfg.Database, steps)
+	case "to":
+		if len(args) < 2 {
+			log.Fatal("usage: server migrate to <version>")
+		}
+		version, convErr := strconv.ParseUint(args[1], 10, 64)
+		if convErr != nil {
+			log.Fatalf("invalid version %q: %v", args[1], convErr)
+		}
+		err = storage.MigrateTo(cfg.Database, uint(version))
+	case "version":
+		var version uint
+		var dirty bool
+		version, dirty, err = storage.MigrateVersion(cfg.Database)
+		if err == nil {
+			fmt.Printf("version=%d dirty=%t\n", version, dirty)
+		}
+	case "force":
+		if len(args) < 2 {
+			log.Fatal("usage: server migrate force <version>")
+		}
+		version, convErr := strconv.Atoi(args[1])
+		if convErr != nil {
+			log.Fatalf("invalid version %q: %v", args[1], convErr)
+		}
+		err = storage.MigrateForce(cfg.Database, version)
+	default:
+		log.Fatalf("unknown migrate subcommand %q", args[0])
+	}
+
+	if err != nil {
+		log.Fatalf("migrate %s failed: %v", args[0], err)
+	}
+
+	os.Exit(0)
+}
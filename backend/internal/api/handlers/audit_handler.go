@@ -0,0 +1,103 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/bartosz/stocks-out-for-harambe/backend/internal/audit"
+	"github.com/bartosz/stocks-out-for-harambe/backend/internal/storage"
+	"github.com/gin-gonic/gin"
+)
+
+// AuditHandler handles the audit-trail read API.
+type AuditHandler struct {
+	db *storage.Database
+}
+
+// NewAuditHandler creates a new audit handler.
+func NewAuditHandler(db *storage.Database) *AuditHandler {
+	return &AuditHandler{db: db}
+}
+
+// auditSortColumns allowlists the columns ListEvents can sort by. Unlike
+// GetDataPoints' sort parameter, this is never interpolated without being
+// checked against this list first, since audit events are exactly the kind
+// of sensitive data an injected ORDER BY could exfiltrate.
+var auditSortColumns = map[string]bool{
+	"timestamp":     true,
+	"actor":         true,
+	"verb":          true,
+	"resource_type": true,
+}
+
+// ListEvents returns audit events, filterable by resource type/id, actor,
+// verb, a timestamp range, and free-text search, with pagination.
+func (h *AuditHandler) ListEvents(c *gin.Context) {
+	query := h.db.DB.Model(&audit.Event{})
+
+	if resourceType := c.Query("resource_type"); resourceType != "" {
+		query = query.Where("resource_type = ?", resourceType)
+	}
+	if resourceID := c.Query("resource_id"); resourceID != "" {
+		query = query.Where("resource_id = ?", resourceID)
+	}
+	if actor := c.Query("actor"); actor != "" {
+		query = query.Where("actor = ?", actor)
+	}
+	if verb := c.Query("verb"); verb != "" {
+		query = query.Where("verb = ?", verb)
+	}
+	if from := c.Query("from"); from != "" {
+		if t, err := time.Parse(time.RFC3339, from); err == nil {
+			query = query.Where("timestamp >= ?", t)
+		} else {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "from must be an RFC3339 timestamp"})
+			return
+		}
+	}
+	if to := c.Query("to"); to != "" {
+		if t, err := time.Parse(time.RFC3339, to); err == nil {
+			query = query.Where("timestamp <= ?", t)
+		} else {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "to must be an RFC3339 timestamp"})
+			return
+		}
+	}
+	if q := c.Query("q"); q != "" {
+		like := "%" + q + "%"
+		query = query.Where("actor ILIKE ? OR resource_id ILIKE ? OR before ILIKE ? OR after ILIKE ?", like, like, like, like)
+	}
+
+	sortColumn := c.DefaultQuery("sort", "timestamp")
+	if !auditSortColumns[sortColumn] {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid sort column"})
+		return
+	}
+	order := "desc"
+	if c.Query("order") == "asc" {
+		order = "asc"
+	}
+
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	if page < 1 {
+		page = 1
+	}
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "20"))
+	if limit <= 0 || limit > 100 {
+		limit = 20
+	}
+	offset := (page - 1) * limit
+
+	var events []audit.Event
+	if err := query.Order(sortColumn + " " + order).Offset(offset).Limit(limit).Find(&events).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch audit events"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"events": events,
+		"page":   page,
+		"limit":  limit,
+	})
+}
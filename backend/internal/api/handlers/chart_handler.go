@@ -1,6 +1,8 @@
 package handlers
 
 import (
+	cryptorand "crypto/rand"
+	"encoding/binary"
 	"math"
 	"math/rand"
 	"net/http"
@@ -8,6 +10,9 @@ import (
 	"strings"
 	"time"
 
+	"github.com/bartosz/stocks-out-for-harambe/backend/internal/audit"
+	"github.com/bartosz/stocks-out-for-harambe/backend/internal/datasets"
+	"github.com/bartosz/stocks-out-for-harambe/backend/internal/dsquery"
 	"github.com/bartosz/stocks-out-for-harambe/backend/internal/models"
 	"github.com/bartosz/stocks-out-for-harambe/backend/internal/storage"
 	"github.com/gin-gonic/gin"
@@ -18,11 +23,6 @@ type ChartHandler struct {
 	db *storage.Database
 }
 
-// randFloat returns a random float64 in [min, max).
-func randFloat(min, max float64) float64 {
-	return min + rand.Float64()*(max-min)
-}
-
 // NewChartHandler creates a new chart handler
 func NewChartHandler(db *storage.Database) *ChartHandler {
 	return &ChartHandler{db: db}
@@ -91,6 +91,8 @@ func (h *ChartHandler) CreateDataset(c *gin.Context) {
 		return
 	}
 
+	audit.Record(c.Request.Context(), "create", "dataset", dataset.ID, nil, dataset)
+
 	c.JSON(http.StatusCreated, dataset.ToResponse(false))
 }
 
@@ -98,11 +100,14 @@ func (h *ChartHandler) CreateDataset(c *gin.Context) {
 func (h *ChartHandler) GetDataset(c *gin.Context) {
 	id := c.Param("id")
 	includeData := c.Query("include_data") == "true"
+	targetPoints, _ := strconv.Atoi(c.Query("downsample"))
 
 	var dataset models.Dataset
 	query := h.db.DB
 
-	if includeData {
+	// Downsampling fetches data points itself via a streamed query, so it
+	// never Preloads the full association only to immediately reduce it.
+	if includeData && targetPoints <= 0 {
 		query = query.Preload("DataPoints")
 	}
 
@@ -111,7 +116,19 @@ func (h *ChartHandler) GetDataset(c *gin.Context) {
 		return
 	}
 
-	c.JSON(http.StatusOK, dataset.ToResponse(includeData))
+	response := dataset.ToResponse(includeData && targetPoints <= 0)
+
+	if includeData && targetPoints > 0 {
+		mode := datasets.Mode(c.Query("mode"))
+		points, err := datasets.Downsample(c.Request.Context(), h.db, dataset.ID, targetPoints, datasets.Options{Mode: mode})
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		response.DataPoints = points
+	}
+
+	c.JSON(http.StatusOK, response)
 }
 
 // UpdateDataset updates a dataset
@@ -129,6 +146,7 @@ func (h *ChartHandler) UpdateDataset(c *gin.Context) {
 		c.JSON(http.StatusNotFound, gin.H{"error": "Dataset not found"})
 		return
 	}
+	before := dataset
 
 	// Update fields
 	if req.Name != "" {
@@ -149,6 +167,11 @@ func (h *ChartHandler) UpdateDataset(c *gin.Context) {
 		return
 	}
 
+	// Dataset.AfterUpdate's hook-based audit event has no access to the
+	// pre-update row; this handler does, so it records the real diff
+	// directly rather than leaving Before empty.
+	audit.Record(c.Request.Context(), "update", "dataset", dataset.ID, before, dataset)
+
 	c.JSON(http.StatusOK, dataset.ToResponse(false))
 }
 
@@ -156,6 +179,12 @@ func (h *ChartHandler) UpdateDataset(c *gin.Context) {
 func (h *ChartHandler) DeleteDataset(c *gin.Context) {
 	id := c.Param("id")
 
+	var dataset models.Dataset
+	if err := h.db.First(&dataset, id).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Dataset not found"})
+		return
+	}
+
 	// Delete data points first
 	if err := h.db.Where("dataset_id = ?", id).Delete(&models.ChartDataPoint{}).Error; err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to delete data points"})
@@ -168,6 +197,8 @@ func (h *ChartHandler) DeleteDataset(c *gin.Context) {
 		return
 	}
 
+	audit.Record(c.Request.Context(), "delete", "dataset", dataset.ID, dataset, nil)
+
 	c.JSON(http.StatusOK, gin.H{"message": "Dataset deleted successfully"})
 }
 
@@ -215,6 +246,8 @@ func (h *ChartHandler) AddDataPoints(c *gin.Context) {
 		return
 	}
 
+	audit.Record(c.Request.Context(), "add_points", "dataset", dataset.ID, nil, dataPoints)
+
 	c.JSON(http.StatusCreated, gin.H{
 		"message": "Data points added successfully",
 		"count":   len(dataPoints),
@@ -280,45 +313,114 @@ func (h *ChartHandler) DeleteDataPoint(c *gin.Context) {
 }
 
 // GenerateSampleData generates sample data for development/testing
+
+// sampleRNG returns a *rand.Rand seeded deterministically from the ?seed=
+// query param when present, so two requests with the same seed produce
+// identical series. Without one, it draws a seed from crypto/rand — using
+// the process-global rand.Seed here would be a data race across concurrent
+// requests and is a no-op anyway on Go 1.20+, which auto-seeds the global
+// source.
+func sampleRNG(c *gin.Context) (*rand.Rand, int64) {
+	if seedParam := c.Query("seed"); seedParam != "" {
+		if seed, err := strconv.ParseInt(seedParam, 10, 64); err == nil {
+			return rand.New(rand.NewSource(seed)), seed
+		}
+	}
+
+	var buf [8]byte
+	if _, err := cryptorand.Read(buf[:]); err != nil {
+		seed := time.Now().UnixNano()
+		return rand.New(rand.NewSource(seed)), seed
+	}
+	seed := int64(binary.BigEndian.Uint64(buf[:]))
+	return rand.New(rand.NewSource(seed)), seed
+}
+
+// stdNormal draws Z ~ N(0,1) via the Box-Muller transform.
+func stdNormal(rng *rand.Rand) float64 {
+	u1 := rng.Float64()
+	for u1 == 0 {
+		u1 = rng.Float64()
+	}
+	u2 := rng.Float64()
+	return math.Sqrt(-2*math.Log(u1)) * math.Cos(2*math.Pi*u2)
+}
+
+// poisson draws a Poisson(lambda)-distributed integer via Knuth's algorithm.
+func poisson(rng *rand.Rand, lambda float64) int {
+	l := math.Exp(-lambda)
+	k := 0
+	p := 1.0
+	for {
+		k++
+		p *= rng.Float64()
+		if p <= l {
+			return k - 1
+		}
+	}
+}
+
+func queryFloat(c *gin.Context, key string, fallback float64) float64 {
+	if raw := c.Query(key); raw != "" {
+		if v, err := strconv.ParseFloat(raw, 64); err == nil {
+			return v
+		}
+	}
+	return fallback
+}
+
+// GenerateSampleData returns a generated series of the requested type
+// (stock, sinusoidal, analytics, or a noisy linear trend otherwise) together
+// with the seed and model parameters that produced it.
 func (h *ChartHandler) GenerateSampleData(c *gin.Context) {
 	dataType := c.Param("type")
 	points, _ := strconv.Atoi(c.DefaultQuery("points", "20"))
 
+	rng, seed := sampleRNG(c)
+
 	var data []models.ChartDataPointResponse
 	now := time.Now()
+	params := gin.H{}
 
 	switch dataType {
 	case "stock":
-		// Generate realistic stock-like data using a simple random walk
-		// Seed RNG for sample generation (safe to reseed here for endpoint scope)
-		rand.Seed(time.Now().UnixNano())
-
-		currentPrice := 100.0
-		volatility := 0.05
-		drift := 0.001
+		// Geometric Brownian motion: S_{t+1} = S_t * exp((mu - 0.5*sigma^2)*dt + sigma*sqrt(dt)*Z)
+		s0 := queryFloat(c, "s0", 100.0)
+		mu := queryFloat(c, "mu", 0.05)
+		sigma := queryFloat(c, "sigma", 0.2)
+		dt := queryFloat(c, "dt", 1.0/252.0)
+		params = gin.H{"s0": s0, "mu": mu, "sigma": sigma, "dt": dt}
+
+		price := s0
+		drift := (mu - 0.5*sigma*sigma) * dt
+		diffusionCoeff := sigma * math.Sqrt(dt)
 		for i := 0; i < points; i++ {
 			timestamp := now.Add(time.Duration(-points+i) * 24 * time.Hour)
-			// change ~ U[-volatility, +volatility] + drift
-			change := randFloat(-volatility, volatility) + drift
-			currentPrice *= (1 + change)
+			z := stdNormal(rng)
+			price *= math.Exp(drift + diffusionCoeff*z)
 
 			data = append(data, models.ChartDataPointResponse{
 				X:         float64(i),
-				Y:         math.Round(currentPrice*100) / 100,
+				Y:         math.Round(price*100) / 100,
 				Label:     timestamp.Format("Jan 02"),
 				Timestamp: timestamp,
 			})
 		}
 
 	case "sinusoidal":
-		// Generate sine wave data
-		amplitude := 30.0
-		frequency := 1.0
-		offset := 50.0
+		amplitude := queryFloat(c, "amplitude", 30.0)
+		frequency := queryFloat(c, "frequency", 1.0)
+		phase := queryFloat(c, "phase", 0.0)
+		offset := queryFloat(c, "offset", 50.0)
+		noiseStdDev := queryFloat(c, "noise", 0.0)
+		params = gin.H{"amplitude": amplitude, "frequency": frequency, "phase": phase, "offset": offset, "noise": noiseStdDev}
 
 		for i := 0; i < points; i++ {
-			x := (float64(i) / float64(points)) * 4 * math.Pi * frequency
+			x := (float64(i)/float64(points))*4*math.Pi*frequency + phase
 			y := amplitude*math.Sin(x) + offset
+			if noiseStdDev > 0 {
+				y += stdNormal(rng) * noiseStdDev
+			}
 
 			data = append(data, models.ChartDataPointResponse{
 				X:     float64(i),
@@ -328,38 +430,47 @@ func (h *ChartHandler) GenerateSampleData(c *gin.Context) {
 		}
 
 	case "analytics":
-		// Generate analytics-like data (page views, etc.)
+		// Weekly seasonality via a sinusoidal component over a 7-day period,
+		// plus a Poisson-distributed spike for the occasional viral day.
+		baseTraffic := queryFloat(c, "base_traffic", 1000.0)
+		seasonalAmplitude := queryFloat(c, "seasonal_amplitude", 300.0)
+		spikeProbability := queryFloat(c, "spike_probability", 0.05)
+		spikeLambda := queryFloat(c, "spike_lambda", 8.0)
+		params = gin.H{
+			"base_traffic":       baseTraffic,
+			"seasonal_amplitude": seasonalAmplitude,
+			"spike_probability":  spikeProbability,
+			"spike_lambda":       spikeLambda,
+		}
+
 		for i := 0; i < points; i++ {
 			timestamp := now.Add(time.Duration(-points+i) * 24 * time.Hour)
-			dayOfWeek := timestamp.Weekday()
 
-			// Higher traffic on weekdays
-			baseTraffic := 1000.0
-			if dayOfWeek == time.Saturday || dayOfWeek == time.Sunday {
-				baseTraffic = 500.0
-			}
+			seasonal := seasonalAmplitude * math.Sin(2*math.Pi*float64(i)/7.0)
+			traffic := baseTraffic + seasonal + stdNormal(rng)*(baseTraffic*0.02)
 
-			// variation ~ U[-300, 300]
-			variation := randFloat(-300, 300)
-			traffic := baseTraffic + variation
+			if rng.Float64() < spikeProbability {
+				traffic += float64(poisson(rng, spikeLambda)) * (baseTraffic * 0.1)
+			}
 
 			data = append(data, models.ChartDataPointResponse{
 				X:         float64(i),
-				Y:         math.Round(traffic),
+				Y:         math.Round(math.Max(0, traffic)),
 				Label:     timestamp.Format("Jan 02"),
 				Timestamp: timestamp,
 			})
 		}
 
 	default:
-		// Generate simple linear data with noise
+		// Simple linear trend with additive Gaussian noise.
+		baseValue := queryFloat(c, "base_value", 50.0)
+		trendPerStep := queryFloat(c, "trend", 2.0)
+		noiseStdDev := queryFloat(c, "noise", 5.0)
+		params = gin.H{"base_value": baseValue, "trend": trendPerStep, "noise": noiseStdDev}
+
 		for i := 0; i < points; i++ {
 			timestamp := now.Add(time.Duration(-points+i) * time.Hour)
-			baseValue := 50.0
-			trend := float64(i) * 2
-			// noise ~ U[-10, 10]
-			noise := randFloat(-10, 10)
-			value := baseValue + trend + noise
+			value := baseValue + trendPerStep*float64(i) + stdNormal(rng)*noiseStdDev
 
 			data = append(data, models.ChartDataPointResponse{
 				X:         float64(i),
@@ -375,5 +486,127 @@ func (h *ChartHandler) GenerateSampleData(c *gin.Context) {
 		"data_points":  data,
 		"count":        len(data),
 		"generated_at": time.Now(),
+		"seed":         seed,
+		"params":       params,
+	})
+}
+
+// QueryDatasetRequest is the body of a dsquery expression against a
+// dataset's data points.
+type QueryDatasetRequest struct {
+	Query string `json:"query" binding:"required"`
+	Start *int64 `json:"start"` // unix seconds; defaults to 24h before end
+	End   *int64 `json:"end"`   // unix seconds; defaults to now
+	Step  string `json:"step"`  // duration string, e.g. "1h"; defaults to 1h
+	Stats string `json:"stats"` // "all" includes samples_per_step in the response
+}
+
+// datasetSampleSource implements dsquery.SampleSource against a single
+// dataset's ChartDataPoints. A label="..." matcher filters on the point's
+// Label field; the selector's name and any other matchers are ignored, so
+// every selector reads the same dataset.
+type datasetSampleSource struct {
+	db        *storage.Database
+	datasetID string
+
+	// samplesPerStep is not populated by Query; the samples_per_step value
+	// QueryDataset returns for stats=all is each result series' point count.
+	samplesPerStep map[int64]int
+}
+
+func (s *datasetSampleSource) Query(name string, matchers map[string]string) ([]dsquery.Sample, error) {
+	query := s.db.Where("dataset_id = ?", s.datasetID)
+	if label, ok := matchers["label"]; ok {
+		query = query.Where("label = ?", label)
+	}
+
+	var points []models.ChartDataPoint
+	if err := query.Order("x asc").Find(&points).Error; err != nil {
+		return nil, err
+	}
+
+	samples := make([]dsquery.Sample, 0, len(points))
+	for _, p := range points {
+		t := p.Timestamp
+		if t.IsZero() {
+			t = time.Unix(int64(p.X), 0)
+		}
+		samples = append(samples, dsquery.Sample{T: t, V: p.Y})
+	}
+	return samples, nil
+}
+
+// QueryDataset evaluates a PromQL-inspired dsquery expression (selectors,
+// *_over_time aggregations, rate/delta, quantile, and arithmetic between
+// series) against a dataset's data points.
+func (h *ChartHandler) QueryDataset(c *gin.Context) {
+	id := c.Param("id")
+
+	var dataset models.Dataset
+	if err := h.db.First(&dataset, id).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Dataset not found"})
+		return
+	}
+
+	var req QueryDatasetRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	node, err := dsquery.Parse(req.Query)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	end := time.Now()
+	if req.End != nil {
+		end = time.Unix(*req.End, 0)
+	}
+	start := end.Add(-24 * time.Hour)
+	if req.Start != nil {
+		start = time.Unix(*req.Start, 0)
+	}
+	step := time.Hour
+	if req.Step != "" {
+		parsed, err := time.ParseDuration(req.Step)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid step: " + err.Error()})
+			return
+		}
+		step = parsed
+	}
+
+	src := &datasetSampleSource{db: h.db, datasetID: id}
+	series, err := dsquery.Eval(node, src, dsquery.Range{Start: start, End: end, Step: step})
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	result := make([]gin.H, 0, len(series))
+	for _, s := range series {
+		points := make([]gin.H, 0, len(s.Points))
+		for _, p := range s.Points {
+			points = append(points, gin.H{"t": p.T.Unix(), "v": p.V})
+		}
+		entry := gin.H{
+			"name":   s.Name,
+			"labels": s.Labels,
+			"points": points,
+		}
+		if req.Stats == "all" {
+			entry["samples_per_step"] = len(s.Points)
+		}
+		result = append(result, entry)
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"query":  req.Query,
+		"start":  start.Unix(),
+		"end":    end.Unix(),
+		"step":   step.String(),
+		"result": result,
 	})
 }
@@ -0,0 +1,105 @@
+package handlers
+
+import (
+	"math"
+	"math/rand"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestSampleRNG_SameSeedProducesSameDraws(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	newCtx := func(seed string) *gin.Context {
+		c, _ := gin.CreateTestContext(httptest.NewRecorder())
+		req := httptest.NewRequest("GET", "/?seed="+seed, nil)
+		c.Request = req
+		return c
+	}
+
+	rng1, seed1 := sampleRNG(newCtx("42"))
+	rng2, seed2 := sampleRNG(newCtx("42"))
+
+	if seed1 != 42 || seed2 != 42 {
+		t.Fatalf("expected both seeds to resolve to 42, got %d and %d", seed1, seed2)
+	}
+	for i := 0; i < 5; i++ {
+		a, b := rng1.Float64(), rng2.Float64()
+		if a != b {
+			t.Fatalf("draw %d diverged: %v vs %v", i, a, b)
+		}
+	}
+}
+
+func TestSampleRNG_InvalidSeedFallsBackToRandom(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	c, _ := gin.CreateTestContext(httptest.NewRecorder())
+	c.Request = httptest.NewRequest("GET", "/?seed=not-a-number", nil)
+
+	rng, _ := sampleRNG(c)
+	if rng == nil {
+		t.Fatal("expected a non-nil *rand.Rand even when the seed param is invalid")
+	}
+}
+
+func TestStdNormal_MeanAndVarianceApproachExpected(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	const n = 20000
+	var sum, sumSq float64
+	for i := 0; i < n; i++ {
+		z := stdNormal(rng)
+		sum += z
+		sumSq += z * z
+	}
+	mean := sum / n
+	variance := sumSq/n - mean*mean
+
+	if math.Abs(mean) > 0.05 {
+		t.Errorf("expected mean near 0 over %d draws, got %v", n, mean)
+	}
+	if math.Abs(variance-1) > 0.1 {
+		t.Errorf("expected variance near 1 over %d draws, got %v", n, variance)
+	}
+}
+
+func TestPoisson_NonNegativeAndApproximatesLambda(t *testing.T) {
+	rng := rand.New(rand.NewSource(2))
+	const lambda = 4.0
+	const n = 20000
+	sum := 0
+	for i := 0; i < n; i++ {
+		k := poisson(rng, lambda)
+		if k < 0 {
+			t.Fatalf("poisson draw was negative: %d", k)
+		}
+		sum += k
+	}
+	mean := float64(sum) / n
+	if math.Abs(mean-lambda) > 0.2 {
+		t.Errorf("expected mean near lambda=%v over %d draws, got %v", lambda, n, mean)
+	}
+}
+
+func TestQueryFloat_ParsesOrFallsBack(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	c, _ := gin.CreateTestContext(httptest.NewRecorder())
+	c.Request = httptest.NewRequest("GET", "/?volatility=0.25", nil)
+	if got := queryFloat(c, "volatility", 1.0); got != 0.25 {
+		t.Errorf("queryFloat = %v, want 0.25", got)
+	}
+
+	c2, _ := gin.CreateTestContext(httptest.NewRecorder())
+	c2.Request = httptest.NewRequest("GET", "/", nil)
+	if got := queryFloat(c2, "volatility", 1.0); got != 1.0 {
+		t.Errorf("queryFloat with missing param = %v, want fallback 1.0", got)
+	}
+
+	c3, _ := gin.CreateTestContext(httptest.NewRecorder())
+	c3.Request = httptest.NewRequest("GET", "/?volatility=not-a-float", nil)
+	if got := queryFloat(c3, "volatility", 1.0); got != 1.0 {
+		t.Errorf("queryFloat with unparseable param = %v, want fallback 1.0", got)
+	}
+}
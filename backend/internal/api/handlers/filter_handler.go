@@ -0,0 +1,173 @@
+package handlers
+
+import (
+	"log"
+	"net/http"
+
+	"github.com/bartosz/stocks-out-for-harambe/backend/internal/audit"
+	"github.com/bartosz/stocks-out-for-harambe/backend/internal/filtering"
+	"github.com/bartosz/stocks-out-for-harambe/backend/internal/models"
+	"github.com/bartosz/stocks-out-for-harambe/backend/internal/storage"
+	"github.com/gin-gonic/gin"
+)
+
+// FilterHandler handles CRUD for models.Filter. Every mutation refreshes
+// the shared filtering.Engine so TextHandler and SitemapHandler match
+// against the current filter set on their very next request.
+type FilterHandler struct {
+	db     *storage.Database
+	engine *filtering.Engine
+}
+
+// NewFilterHandler creates a new filter handler.
+func NewFilterHandler(db *storage.Database, engine *filtering.Engine) *FilterHandler {
+	return &FilterHandler{db: db, engine: engine}
+}
+
+// refreshEngine rebuilds the matcher after a mutation. A refresh failure is
+// logged rather than surfaced as a request error: the mutation itself
+// already succeeded, and the previous matcher stays in place until the
+// next successful refresh.
+func (h *FilterHandler) refreshEngine() {
+	if err := h.engine.Refresh(h.db); err != nil {
+		log.Printf("Warning: failed to refresh filter engine: %v", err)
+	}
+}
+
+// ListFilters returns all filters.
+func (h *FilterHandler) ListFilters(c *gin.Context) {
+	// Lazily sweep expired filters before reading: nothing else runs on a
+	// schedule, so a read is the only reliable place to catch a filter
+	// whose expires_at has passed since the last mutation.
+	h.refreshEngine()
+
+	var filters []models.Filter
+	if err := h.db.Find(&filters).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch filters"})
+		return
+	}
+
+	responses := make([]models.FilterResponse, len(filters))
+	for i, f := range filters {
+		responses[i] = f.ToResponse()
+	}
+
+	c.JSON(http.StatusOK, gin.H{"filters": responses})
+}
+
+// CreateFilter creates a new filter.
+func (h *FilterHandler) CreateFilter(c *gin.Context) {
+	var req models.CreateFilterRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	wholeWord := true
+	if req.WholeWord != nil {
+		wholeWord = *req.WholeWord
+	}
+
+	filter := models.Filter{
+		Phrase:       req.Phrase,
+		Context:      req.Context,
+		WholeWord:    wholeWord,
+		Irreversible: req.Irreversible,
+		ExpiresAt:    req.ExpiresAt,
+	}
+
+	if err := h.db.Create(&filter).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create filter"})
+		return
+	}
+
+	audit.Record(c.Request.Context(), "create", "filter", filter.ID, nil, filter)
+
+	h.refreshEngine()
+	c.JSON(http.StatusCreated, filter.ToResponse())
+}
+
+// GetFilter returns a single filter by ID.
+func (h *FilterHandler) GetFilter(c *gin.Context) {
+	// See ListFilters: the sweep is what makes expired filters 404 here
+	// instead of lingering until the next CRUD-triggered refresh.
+	h.refreshEngine()
+
+	id := c.Param("id")
+
+	var filter models.Filter
+	if err := h.db.First(&filter, id).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Filter not found"})
+		return
+	}
+
+	c.JSON(http.StatusOK, filter.ToResponse())
+}
+
+// UpdateFilter updates a filter.
+func (h *FilterHandler) UpdateFilter(c *gin.Context) {
+	id := c.Param("id")
+
+	var req models.UpdateFilterRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	var filter models.Filter
+	if err := h.db.First(&filter, id).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Filter not found"})
+		return
+	}
+	before := filter
+
+	if req.Phrase != "" {
+		filter.Phrase = req.Phrase
+	}
+	if req.Context != nil {
+		filter.Context = req.Context
+	}
+	if req.WholeWord != nil {
+		filter.WholeWord = *req.WholeWord
+	}
+	if req.Irreversible != nil {
+		filter.Irreversible = *req.Irreversible
+	}
+	if req.ExpiresAt != nil {
+		filter.ExpiresAt = req.ExpiresAt
+	}
+
+	if err := h.db.Save(&filter).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update filter"})
+		return
+	}
+
+	// Filter.AfterUpdate's hook-based audit event has no access to the
+	// pre-update row; this handler does, so it records the real diff
+	// directly rather than leaving Before empty.
+	audit.Record(c.Request.Context(), "update", "filter", filter.ID, before, filter)
+
+	h.refreshEngine()
+	c.JSON(http.StatusOK, filter.ToResponse())
+}
+
+// DeleteFilter deletes a filter.
+func (h *FilterHandler) DeleteFilter(c *gin.Context) {
+	id := c.Param("id")
+
+	var filter models.Filter
+	if err := h.db.First(&filter, id).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Filter not found"})
+		return
+	}
+
+	if err := h.db.Delete(&models.Filter{}, id).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to delete filter"})
+		return
+	}
+
+	audit.Record(c.Request.Context(), "delete", "filter", filter.ID, filter, nil)
+
+	h.refreshEngine()
+	c.JSON(http.StatusOK, gin.H{"message": "Filter deleted successfully"})
+}
@@ -0,0 +1,103 @@
+//go:build integration
+
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/bartosz/stocks-out-for-harambe/backend/internal/filtering"
+	"github.com/bartosz/stocks-out-for-harambe/backend/internal/models"
+	"github.com/bartosz/stocks-out-for-harambe/backend/internal/storage"
+	"github.com/bartosz/stocks-out-for-harambe/backend/internal/storage/testsupport"
+	"github.com/gin-gonic/gin"
+)
+
+// newFilterTestRouter wires a bare FilterHandler the same way router.go
+// does, minus everything unrelated to filters, so these tests exercise
+// real Gin binding and real GORM queries against testsupport's container.
+func newFilterTestRouter(db *storage.Database) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	handler := NewFilterHandler(db, filtering.NewEngine())
+
+	r := gin.New()
+	filtersGroup := r.Group("/filters")
+	{
+		filtersGroup.GET("", handler.ListFilters)
+		filtersGroup.POST("", handler.CreateFilter)
+		filtersGroup.GET("/:id", handler.GetFilter)
+		filtersGroup.PUT("/:id", handler.UpdateFilter)
+		filtersGroup.DELETE("/:id", handler.DeleteFilter)
+	}
+	return r
+}
+
+func TestFilterHandler_CreateAndList(t *testing.T) {
+	testsupport.WithCleanDB(t, func(db *storage.Database) {
+		r := newFilterTestRouter(db)
+
+		body, _ := json.Marshal(models.CreateFilterRequest{
+			Phrase:  "acme corp",
+			Context: []models.FilterContext{models.FilterContextKeyword},
+		})
+		req := httptest.NewRequest(http.MethodPost, "/filters", bytes.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+
+		if w.Code != http.StatusCreated {
+			t.Fatalf("expected 201, got %d: %s", w.Code, w.Body.String())
+		}
+
+		var created models.FilterResponse
+		if err := json.Unmarshal(w.Body.Bytes(), &created); err != nil {
+			t.Fatalf("failed to decode create response: %v", err)
+		}
+		if created.Phrase != "acme corp" {
+			t.Fatalf("expected phrase %q, got %q", "acme corp", created.Phrase)
+		}
+
+		listW := httptest.NewRecorder()
+		r.ServeHTTP(listW, httptest.NewRequest(http.MethodGet, "/filters", nil))
+
+		var listResp struct {
+			Filters []models.FilterResponse `json:"filters"`
+		}
+		if err := json.Unmarshal(listW.Body.Bytes(), &listResp); err != nil {
+			t.Fatalf("failed to decode list response: %v", err)
+		}
+		if len(listResp.Filters) != 1 {
+			t.Fatalf("expected 1 filter after create, got %d", len(listResp.Filters))
+		}
+	})
+}
+
+func TestFilterHandler_DeleteRemovesFilter(t *testing.T) {
+	testsupport.WithCleanDB(t, func(db *storage.Database) {
+		r := newFilterTestRouter(db)
+
+		filter := models.Filter{Phrase: "temp", Context: []models.FilterContext{models.FilterContextAnalysis}}
+		if err := db.Create(&filter).Error; err != nil {
+			t.Fatalf("failed to seed filter: %v", err)
+		}
+
+		delW := httptest.NewRecorder()
+		r.ServeHTTP(delW, httptest.NewRequest(http.MethodDelete, fmt.Sprintf("/filters/%d", filter.ID), nil))
+
+		if delW.Code != http.StatusOK {
+			t.Fatalf("expected 200, got %d: %s", delW.Code, delW.Body.String())
+		}
+
+		var remaining []models.Filter
+		if err := db.Find(&remaining).Error; err != nil {
+			t.Fatalf("failed to list filters: %v", err)
+		}
+		if len(remaining) != 0 {
+			t.Fatalf("expected filter to be deleted, found %d remaining", len(remaining))
+		}
+	})
+}
@@ -0,0 +1,132 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/bartosz/stocks-out-for-harambe/backend/internal/jobs"
+	"github.com/bartosz/stocks-out-for-harambe/backend/internal/progress"
+	"github.com/gin-gonic/gin"
+)
+
+// JobsHandler exposes the job-manager registry for admin visibility and
+// cancellation.
+type JobsHandler struct {
+	manager        *jobs.Manager
+	progressBroker *progress.Broker
+}
+
+// NewJobsHandler creates a new jobs handler backed by manager. progressBroker
+// backs StreamJobEvents; it's the same Broker passed to NewTextHandler so
+// the events published by BatchAnalyzeText's workers reach this handler's
+// subscribers.
+func NewJobsHandler(manager *jobs.Manager, progressBroker *progress.Broker) *JobsHandler {
+	return &JobsHandler{manager: manager, progressBroker: progressBroker}
+}
+
+// ListJobs returns every job the manager currently knows about.
+func (h *JobsHandler) ListJobs(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{"jobs": h.manager.List()})
+}
+
+// GetJob returns one job's current status and timestamps.
+func (h *JobsHandler) GetJob(c *gin.Context) {
+	id := c.Param("id")
+
+	job, err := h.manager.Get(id)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "job not found"})
+		return
+	}
+
+	c.JSON(http.StatusOK, job)
+}
+
+// CancelJob cancels a queued or running job as an admin action.
+func (h *JobsHandler) CancelJob(c *gin.Context) {
+	id := c.Param("id")
+
+	if err := h.manager.Cancel(id); err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "job not found"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "job cancelled"})
+}
+
+// StreamJobEvents streams a job's progress.Broker events (e.g.
+// "text_completed" ticks from BatchAnalyzeText) as Server-Sent Events. It
+// replaces polling GetJob for clients that want to watch a batch without
+// hammering the registry. A client reconnecting after a dropped connection
+// can send Last-Event-ID (as the standard SSE header, or a last_event_id
+// query param for clients that can't set headers, e.g. EventSource itself)
+// to replay whatever it missed from the Broker's buffer before streaming
+// live.
+func (h *JobsHandler) StreamJobEvents(c *gin.Context) {
+	id := c.Param("id")
+
+	job, err := h.manager.Get(id)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "job not found"})
+		return
+	}
+
+	var lastEventID uint64
+	if v := c.GetHeader("Last-Event-ID"); v != "" {
+		lastEventID, _ = strconv.ParseUint(v, 10, 64)
+	} else if v := c.Query("last_event_id"); v != "" {
+		lastEventID, _ = strconv.ParseUint(v, 10, 64)
+	}
+
+	events, unsubscribe := h.progressBroker.Subscribe(id, lastEventID)
+	defer unsubscribe()
+
+	c.Header("Content-Type", "text/event-stream")
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Connection", "keep-alive")
+	c.Header("X-Accel-Buffering", "no")
+
+	h.writeJobEvent(c, progress.Event{Type: "job_status", Data: job})
+	c.Writer.Flush()
+
+	if job.Status == jobs.StatusCompleted || job.Status == jobs.StatusFailed || job.Status == jobs.StatusCancelled {
+		return
+	}
+
+	ctx := c.Request.Context()
+	heartbeat := time.NewTicker(15 * time.Second)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-heartbeat.C:
+			fmt.Fprint(c.Writer, ": heartbeat\n\n")
+			c.Writer.Flush()
+		case event, ok := <-events:
+			if !ok {
+				return
+			}
+			h.writeJobEvent(c, event)
+			c.Writer.Flush()
+			if event.Type == "job_completed" {
+				return
+			}
+		}
+	}
+}
+
+// writeJobEvent writes a single id-tagged SSE event with a JSON data
+// payload, setting the "id:" field so a reconnecting EventSource populates
+// Last-Event-ID automatically on its next retry.
+func (h *JobsHandler) writeJobEvent(c *gin.Context, event progress.Event) {
+	payload, err := json.Marshal(event.Data)
+	if err != nil {
+		return
+	}
+	fmt.Fprintf(c.Writer, "id: %d\nevent: %s\ndata: %s\n\n", event.ID, event.Type, payload)
+}
@@ -1,15 +1,26 @@
 package handlers
 
 import (
+	"context"
+	"encoding/json"
+	"fmt"
 	"net/http"
 	"strconv"
+	"sync"
 	"time"
 
 	"github.com/bartosz/stocks-out-for-harambe/backend/internal/config"
+	"github.com/bartosz/stocks-out-for-harambe/backend/internal/filtering"
+	"github.com/bartosz/stocks-out-for-harambe/backend/internal/jobs"
+	"github.com/bartosz/stocks-out-for-harambe/backend/internal/metrics"
 	"github.com/bartosz/stocks-out-for-harambe/backend/internal/models"
+	"github.com/bartosz/stocks-out-for-harambe/backend/internal/retry"
 	"github.com/bartosz/stocks-out-for-harambe/backend/internal/services"
+	"github.com/bartosz/stocks-out-for-harambe/backend/internal/sitemapdiscovery"
 	"github.com/bartosz/stocks-out-for-harambe/backend/internal/storage"
+	"github.com/bartosz/stocks-out-for-harambe/backend/internal/urlhash"
 	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
 )
 
 // SitemapHandler handles sitemap and extraction related requests
@@ -17,21 +28,49 @@ type SitemapHandler struct {
 	db              *storage.Database
 	firecrawlClient *services.FirecrawlClient
 	cfg             *config.Config
+	progressHub     *services.ProgressHub
+	discoverer      *sitemapdiscovery.Discoverer
+	jobManager      *jobs.Manager
+	uaClassifier    *services.UAClassifier
+	filterEngine    *filtering.Engine
+
+	// sessionPollers tracks which sessions already have a runSessionPoller
+	// goroutine running, so N concurrent StreamExtractionProgress
+	// subscribers for the same session share one DB poller instead of each
+	// running its own.
+	sessionPollersMu sync.Mutex
+	sessionPollers   map[string]struct{}
 }
 
-// NewSitemapHandler creates a new sitemap handler
-func NewSitemapHandler(db *storage.Database, cfg *config.Config) *SitemapHandler {
+// NewSitemapHandler creates a new sitemap handler. filterEngine matches
+// each submitted URL against the "extraction" context of the active
+// models.Filter set: an irreversible match drops the URL before any
+// extraction record is created, a non-irreversible match lets it through
+// but tags the resulting URLExtraction's Metadata["filtered_by"].
+func NewSitemapHandler(db *storage.Database, cfg *config.Config, jobManager *jobs.Manager, filterEngine *filtering.Engine) *SitemapHandler {
 	return &SitemapHandler{
 		db:              db,
 		firecrawlClient: services.NewFirecrawlClient(cfg),
 		cfg:             cfg,
+		progressHub:     services.NewProgressHub(),
+		discoverer:      sitemapdiscovery.New(nil),
+		jobManager:      jobManager,
+		uaClassifier:    services.NewUAClassifier(),
+		filterEngine:    filterEngine,
+		sessionPollers:  make(map[string]struct{}),
 	}
 }
 
-// DiscoverSitemap discovers URLs from a website's sitemap
+// DiscoverSitemap walks the sitemaps.org protocol for a site: robots.txt
+// "Sitemap:" directives (falling back to /sitemap.xml), recursing into any
+// <sitemapindex> it finds, bounded by max_depth/max_urls. The resulting
+// tree is persisted so StartBatchExtraction can reference it by ID instead
+// of requiring the full URL list in the request body.
 func (h *SitemapHandler) DiscoverSitemap(c *gin.Context) {
 	var req struct {
-		BaseURL string `json:"base_url" binding:"required"`
+		BaseURL  string `json:"base_url" binding:"required"`
+		MaxDepth int    `json:"max_depth"`
+		MaxURLs  int    `json:"max_urls"`
 	}
 
 	if err := c.ShouldBindJSON(&req); err != nil {
@@ -42,31 +81,100 @@ func (h *SitemapHandler) DiscoverSitemap(c *gin.Context) {
 		return
 	}
 
-	// Call firecrawl service to discover sitemap URLs
-	discovery, err := h.firecrawlClient.DiscoverSitemap(c.Request.Context(), req.BaseURL)
+	discoverer := h.discoverer
+	if req.MaxDepth > 0 || req.MaxURLs > 0 {
+		discoverer = &sitemapdiscovery.Discoverer{
+			HTTPClient: h.discoverer.HTTPClient,
+			MaxDepth:   req.MaxDepth,
+			MaxURLs:    req.MaxURLs,
+		}
+	}
+
+	result, err := discoverer.Discover(c.Request.Context(), req.BaseURL)
 	if err != nil {
-		c.JSON(http.StatusServiceUnavailable, gin.H{
+		c.JSON(http.StatusBadGateway, gin.H{
 			"error":   "Failed to discover sitemap",
 			"details": err.Error(),
 		})
 		return
 	}
 
+	tree, err := treeToJSONMap(result)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "Failed to serialize discovery tree",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	discovery := &models.SitemapDiscovery{
+		BaseURL:   req.BaseURL,
+		Tree:      tree,
+		TotalURLs: result.TotalURLs,
+	}
+	if err := h.db.DB.Create(discovery).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "Failed to persist discovery tree",
+			"details": err.Error(),
+		})
+		return
+	}
+
 	c.JSON(http.StatusOK, gin.H{
 		"success": true,
-		"data":    discovery,
+		"data": gin.H{
+			"discovery_id":   discovery.DiscoveryID,
+			"base_url":       result.BaseURL,
+			"robots_found":   result.RobotsFound,
+			"roots":          result.Roots,
+			"total_urls":     result.TotalURLs,
+			"truncated_urls": result.TruncatedURLs,
+		},
 	})
 }
 
+// treeToJSONMap round-trips a discovery Result through JSON so it can be
+// stored in the jsonb Tree column, matching how other models store
+// structured data (see ExtractionSession.Metadata).
+func treeToJSONMap(result *sitemapdiscovery.Result) (map[string]interface{}, error) {
+	raw, err := json.Marshal(result)
+	if err != nil {
+		return nil, err
+	}
+	var m map[string]interface{}
+	if err := json.Unmarshal(raw, &m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// flattenDiscoveryTree is the inverse of treeToJSONMap: it round-trips a
+// persisted SitemapDiscovery.Tree back into a sitemapdiscovery.Result and
+// flattens its roots down to a plain URL list.
+func flattenDiscoveryTree(tree map[string]interface{}) ([]string, error) {
+	raw, err := json.Marshal(tree)
+	if err != nil {
+		return nil, err
+	}
+	var result sitemapdiscovery.Result
+	if err := json.Unmarshal(raw, &result); err != nil {
+		return nil, err
+	}
+	return sitemapdiscovery.FlattenURLs(result.Roots), nil
+}
+
 // StartBatchExtraction starts a batch extraction process
 func (h *SitemapHandler) StartBatchExtraction(c *gin.Context) {
 	var req struct {
-		UserID      string   `json:"user_id" binding:"required"`
-		SourceURL   string   `json:"source_url" binding:"required"`
-		SessionName string   `json:"session_name"`
-		URLs        []string `json:"urls" binding:"required"`
-		ChunkSize   int      `json:"chunk_size"`
-		MaxRetries  int      `json:"max_retries"`
+		UserID      string       `json:"user_id" binding:"required"`
+		SourceURL   string       `json:"source_url" binding:"required"`
+		SessionName string       `json:"session_name"`
+		URLs        []string     `json:"urls"`
+		DiscoveryID string       `json:"discovery_id"`
+		ChunkSize   int          `json:"chunk_size"`
+		MaxRetries  int          `json:"max_retries"`
+		RetryPolicy retry.Policy `json:"retry_policy"`
 	}
 
 	if err := c.ShouldBindJSON(&req); err != nil {
@@ -77,6 +185,30 @@ func (h *SitemapHandler) StartBatchExtraction(c *gin.Context) {
 		return
 	}
 
+	// Either a discovery_id (a previously persisted sitemap discovery tree)
+	// or an explicit urls list is required, so huge discovered sets don't
+	// have to round-trip through the request body.
+	if req.DiscoveryID != "" {
+		var discovery models.SitemapDiscovery
+		if err := h.db.DB.Where("discovery_id = ?", req.DiscoveryID).First(&discovery).Error; err != nil {
+			c.JSON(http.StatusNotFound, gin.H{"error": "discovery_id not found"})
+			return
+		}
+		urls, err := flattenDiscoveryTree(discovery.Tree)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"error":   "Failed to read discovery tree",
+				"details": err.Error(),
+			})
+			return
+		}
+		req.URLs = append(req.URLs, urls...)
+	}
+	if len(req.URLs) == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "either urls or discovery_id with a non-empty tree is required"})
+		return
+	}
+
 	// Set defaults
 	if req.ChunkSize == 0 {
 		req.ChunkSize = 10
@@ -84,6 +216,26 @@ func (h *SitemapHandler) StartBatchExtraction(c *gin.Context) {
 	if req.MaxRetries == 0 {
 		req.MaxRetries = 3
 	}
+	if req.RetryPolicy.Strategy == "" {
+		req.RetryPolicy = retry.DefaultPolicy()
+	}
+	if _, err := req.RetryPolicy.Build(); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid retry_policy",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	// Classify whoever submitted this batch. FirecrawlClient's own response
+	// to StartBatchExtraction doesn't carry per-page User-Agent/Server
+	// headers (the actual page fetches happen in the external firecrawl
+	// service, with no webhook callback modeled in this backend), so the
+	// closest real signal available here is the User-Agent header of the
+	// request that submitted the batch itself — useful for telling a
+	// browser-driven human operator apart from a script or crawler hitting
+	// this endpoint directly.
+	requesterUA := h.uaClassifier.Classify(c.Request.UserAgent())
 
 	// Create extraction session in database (let DB generate UUID)
 	session := &models.ExtractionSession{
@@ -97,6 +249,8 @@ func (h *SitemapHandler) StartBatchExtraction(c *gin.Context) {
 			"chunk_size":   req.ChunkSize,
 			"max_retries":  req.MaxRetries,
 			"request_time": time.Now().Unix(),
+			"retry_policy": req.RetryPolicy,
+			"requester_ua": requesterUA,
 		},
 	}
 
@@ -107,28 +261,102 @@ func (h *SitemapHandler) StartBatchExtraction(c *gin.Context) {
 		})
 		return
 	}
+	metrics.IncSessionsInProgress()
 
 	// Get the generated session ID
 	sessionID := session.SessionID
 
+	// With ?dedupe_across_sessions=true, skip re-crawling URLs this user has
+	// already successfully extracted in a prior session, linking the
+	// skipped row back to the extraction it duplicates.
+	crossSessionDedup := c.Query("dedupe_across_sessions") == "true"
+
+	// Dedupe the incoming batch itself first: two URLs that normalize to the
+	// same hash (e.g. differing only in query-param order) only need one
+	// extraction record, with the rest reported as skipped duplicates.
+	seenInBatch := make(map[string]int, len(req.URLs)) // hash -> first original_index
+	skippedDuplicates := make([]gin.H, 0)
+	skippedFiltered := make([]gin.H, 0)
+	dedupedURLs := make([]string, 0, len(req.URLs))
+
 	// Create URL extraction records
 	urlExtractions := make([]models.URLExtraction, 0, len(req.URLs))
+	chunkPos := 0
 	for i, url := range req.URLs {
-		chunkNumber := i / req.ChunkSize
-		positionInChunk := i % req.ChunkSize
+		hash := urlhash.Hash(url)
+
+		if firstIndex, dup := seenInBatch[hash]; dup {
+			skippedDuplicates = append(skippedDuplicates, gin.H{
+				"url":                url,
+				"url_hash":           hash,
+				"duplicate_of_index": firstIndex,
+			})
+			continue
+		}
+		seenInBatch[hash] = i
+
+		// Irreversible filters drop the URL before it's ever persisted as an
+		// extraction record; non-irreversible ones let it through but tag
+		// the resulting URLExtraction so the UI can hide/collapse it.
+		var filterMatches []models.Filter
+		if h.filterEngine != nil {
+			filterMatches = h.filterEngine.Match(models.FilterContextExtraction, url)
+		}
+		if filtering.Irreversible(filterMatches) {
+			skippedFiltered = append(skippedFiltered, gin.H{
+				"url":            url,
+				"url_hash":       hash,
+				"matched_by":     filtering.Names(filterMatches),
+				"original_index": i,
+			})
+			continue
+		}
+
+		chunkNumber := chunkPos / req.ChunkSize
+		positionInChunk := chunkPos % req.ChunkSize
+		chunkPos++
+
+		extractionMetadata := map[string]interface{}{
+			"original_index": i,
+			"requester_ua":   requesterUA,
+		}
+		if len(filterMatches) > 0 {
+			extractionMetadata["filtered_by"] = filtering.Names(filterMatches)
+		}
 
 		urlExtraction := models.URLExtraction{
 			SessionID:       sessionID,
 			URL:             url,
-			URLHash:         generateURLHash(url),
+			URLHash:         hash,
 			ChunkNumber:     chunkNumber,
 			PositionInChunk: positionInChunk,
 			Status:          models.ExtractionURLStatusPending,
 			MaxRetries:      req.MaxRetries,
-			Metadata: map[string]interface{}{
-				"original_index": i,
-			},
+			Metadata:        extractionMetadata,
+		}
+
+		// Resolve cross-session dedup before deciding whether to submit
+		// this URL to firecrawl at all: a URL that's already been
+		// successfully extracted elsewhere is persisted as Skipped but
+		// must not also be re-crawled.
+		skippedAsCrossSessionDup := false
+		if crossSessionDedup {
+			var prior models.URLExtraction
+			err := h.db.DB.
+				Where("url_hash = ? AND status = ?", hash, models.ExtractionURLStatusSuccess).
+				Order("created_at DESC").
+				First(&prior).Error
+			if err == nil {
+				urlExtraction.Status = models.ExtractionURLStatusSkipped
+				urlExtraction.Metadata["duplicate_of"] = prior.ExtractionID
+				skippedAsCrossSessionDup = true
+			}
+		}
+
+		if !skippedAsCrossSessionDup {
+			dedupedURLs = append(dedupedURLs, url)
 		}
+
 		urlExtractions = append(urlExtractions, urlExtraction)
 	}
 
@@ -139,21 +367,40 @@ func (h *SitemapHandler) StartBatchExtraction(c *gin.Context) {
 		})
 		return
 	}
+	for _, ue := range urlExtractions {
+		metrics.ObserveURLStatus(string(ue.Status))
+	}
+
+	session.TotalURLs = len(urlExtractions)
+	h.db.DB.Model(session).Update("total_urls", session.TotalURLs)
 
-	// Start batch extraction with firecrawl service
-	extractionResponse, err := h.firecrawlClient.StartBatchExtraction(
-		c.Request.Context(),
-		sessionID,
-		req.URLs,
-		req.ChunkSize,
-		req.MaxRetries,
-	)
+	// Start batch extraction with firecrawl service, as a cancellable job so
+	// a client that hangs up mid-request doesn't hold a worker slot until
+	// the firecrawl timeout fires.
+	extractionTimeout := time.Duration(h.cfg.Firecrawl.ExtractionTimeoutMs) * time.Millisecond
+	_, resultCh := h.jobManager.Submit(c.Request.Context(), jobs.Spec{
+		Kind:    "firecrawl_ingest",
+		Timeout: extractionTimeout,
+		Work: func(ctx context.Context) (interface{}, error) {
+			return h.firecrawlClient.StartBatchExtraction(ctx, sessionID, dedupedURLs, req.ChunkSize, req.MaxRetries)
+		},
+	})
+
+	result := <-resultCh
+	var extractionResponse *services.BatchExtractionResponse
+	if result.Value != nil {
+		extractionResponse = result.Value.(*services.BatchExtractionResponse)
+	}
+	err := result.Err
 	if err != nil {
 		// Update session status to failed
+		completedAt := time.Now()
 		h.db.DB.Model(session).Updates(map[string]interface{}{
 			"status":       models.ExtractionStatusFailed,
-			"completed_at": time.Now(),
+			"completed_at": completedAt,
 		})
+		metrics.DecSessionsInProgress()
+		metrics.ObserveSessionDurationSeconds(completedAt.Sub(session.StartedAt).Seconds())
 
 		c.JSON(http.StatusServiceUnavailable, gin.H{
 			"error":   "Failed to start batch extraction",
@@ -165,10 +412,12 @@ func (h *SitemapHandler) StartBatchExtraction(c *gin.Context) {
 	c.JSON(http.StatusCreated, gin.H{
 		"success": true,
 		"data": gin.H{
-			"session_id": sessionID,
-			"status":     extractionResponse.Status,
-			"total_urls": extractionResponse.TotalURLs,
-			"message":    extractionResponse.Message,
+			"session_id":         sessionID,
+			"status":             extractionResponse.Status,
+			"total_urls":         extractionResponse.TotalURLs,
+			"message":            extractionResponse.Message,
+			"skipped_duplicates": skippedDuplicates,
+			"skipped_filtered":   skippedFiltered,
 		},
 	})
 }
@@ -372,6 +621,13 @@ func (h *SitemapHandler) CancelExtraction(c *gin.Context) {
 		})
 		return
 	}
+	metrics.DecSessionsInProgress()
+	metrics.ObserveSessionDurationSeconds(now.Sub(session.StartedAt).Seconds())
+
+	h.progressHub.Publish(sessionID, services.ExtractionEvent{
+		Type: services.ExtractionEventSessionCompleted,
+		Data: gin.H{"status": models.ExtractionStatusCancelled},
+	})
 
 	c.JSON(http.StatusOK, gin.H{
 		"success": true,
@@ -398,6 +654,62 @@ func (h *SitemapHandler) RetryFailedExtractions(c *gin.Context) {
 		return
 	}
 
+	// Count URL extractions that have already exhausted their retry budget;
+	// the circuit breaker trips the whole session rather than retrying a
+	// source that keeps failing the same URLs.
+	var exhaustedCount int64
+	h.db.DB.Model(&models.URLExtraction{}).
+		Where("session_id = ? AND status = ? AND attempt_count >= max_retries", sessionID, models.ExtractionURLStatusFailed).
+		Count(&exhaustedCount)
+
+	policy := loadRetryPolicy(session.Metadata)
+	breaker := retry.CircuitBreaker{Threshold: policy.CircuitBreakerThreshold}
+	if breaker.ShouldTrip(int(exhaustedCount)) {
+		now := time.Now()
+		h.db.DB.Model(&session).Updates(map[string]interface{}{
+			"status":       models.ExtractionStatusFailed,
+			"completed_at": &now,
+		})
+		if session.Status == models.ExtractionStatusInProgress {
+			metrics.DecSessionsInProgress()
+			metrics.ObserveSessionDurationSeconds(now.Sub(session.StartedAt).Seconds())
+		}
+		c.JSON(http.StatusConflict, gin.H{
+			"error":               "Circuit breaker tripped: too many URL extractions exhausted their retries",
+			"exhausted_url_count": exhaustedCount,
+		})
+		return
+	}
+
+	strategy, err := policy.Build()
+	if err != nil {
+		// A malformed policy shouldn't block retries outright; fall back to
+		// the default rather than 500ing on stored session metadata.
+		strategy, _ = retry.DefaultPolicy().Build()
+	}
+
+	// Load the session's most recent retry record so HTTPStatusAware can
+	// actually see the last failure's HTTPStatus/RetryAfterMs instead of
+	// always deciding blind.
+	var lastRetry *models.ExtractionRetry
+	var latest models.ExtractionRetry
+	err = h.db.DB.
+		Joins("JOIN url_extractions ON url_extractions.extraction_id = extraction_retries.extraction_id").
+		Where("url_extractions.session_id = ?", sessionID).
+		Order("extraction_retries.created_at DESC").
+		First(&latest).Error
+	if err == nil {
+		lastRetry = &latest
+	}
+
+	nextDelay, shouldRetry := strategy.NextDelay(1, lastRetry)
+	if !shouldRetry {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Retry policy does not permit retrying this session's failures",
+		})
+		return
+	}
+
 	// Retry with firecrawl service
 	retryResponse, err := h.firecrawlClient.RetryFailedExtractions(c.Request.Context(), sessionID)
 	if err != nil {
@@ -407,6 +719,11 @@ func (h *SitemapHandler) RetryFailedExtractions(c *gin.Context) {
 		})
 		return
 	}
+	retryErrorType := ""
+	if lastRetry != nil {
+		retryErrorType = lastRetry.ErrorType
+	}
+	metrics.ObserveRetry(retryErrorType, string(policy.Strategy))
 
 	// Update session status if it was completed/failed
 	if session.Status != models.ExtractionStatusInProgress {
@@ -414,14 +731,40 @@ func (h *SitemapHandler) RetryFailedExtractions(c *gin.Context) {
 			"status":       models.ExtractionStatusInProgress,
 			"completed_at": nil,
 		})
+		metrics.IncSessionsInProgress()
 	}
 
 	c.JSON(http.StatusOK, gin.H{
 		"success": true,
 		"data":    retryResponse,
+		"retry": gin.H{
+			"strategy":            policy.Strategy,
+			"next_retry_delay_ms": nextDelay.Milliseconds(),
+		},
 	})
 }
 
+// loadRetryPolicy decodes the retry.Policy stored on a session's metadata
+// (round-tripped through the jsonb column as a map[string]interface{}),
+// falling back to retry.DefaultPolicy when absent or malformed.
+func loadRetryPolicy(metadata map[string]interface{}) retry.Policy {
+	raw, ok := metadata["retry_policy"]
+	if !ok {
+		return retry.DefaultPolicy()
+	}
+
+	encoded, err := json.Marshal(raw)
+	if err != nil {
+		return retry.DefaultPolicy()
+	}
+
+	var policy retry.Policy
+	if err := json.Unmarshal(encoded, &policy); err != nil || policy.Strategy == "" {
+		return retry.DefaultPolicy()
+	}
+	return policy
+}
+
 // DeleteExtractionSession deletes an extraction session and its data
 func (h *SitemapHandler) DeleteExtractionSession(c *gin.Context) {
 	sessionID := c.Param("sessionId")
@@ -456,14 +799,327 @@ func (h *SitemapHandler) DeleteExtractionSession(c *gin.Context) {
 	})
 }
 
-// Helper function to generate URL hash
-func generateURLHash(url string) string {
-	// Simple hash implementation - in production, use a proper hash function
-	hash := 0
-	for _, char := range url {
-		hash = int(char) + ((hash << 5) - hash)
+// GetURLHistory returns every past URLExtraction for a content-addressable
+// URL hash, across all sessions, ordered most-recent-first. This backs the
+// cross-session dedup UX: given a hash a client already has (from a
+// URLExtractionResponse), it can see whether/when that URL was last
+// successfully crawled.
+func (h *SitemapHandler) GetURLHistory(c *gin.Context) {
+	hash := c.Param("hash")
+	if hash == "" {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "hash is required",
+		})
+		return
+	}
+
+	var extractions []models.URLExtraction
+	if err := h.db.DB.Where("url_hash = ?", hash).Order("created_at DESC").Find(&extractions).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "Failed to fetch URL history",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	responses := make([]models.URLExtractionResponse, len(extractions))
+	for i, extraction := range extractions {
+		responses[i] = extraction.ToResponse(false)
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data": gin.H{
+			"url_hash":    hash,
+			"extractions": responses,
+		},
+	})
+}
+
+// extractionAgentsCaveat is surfaced on every GetExtractionAgents response
+// rather than left to a Go doc comment, so API consumers (not just readers
+// of this source file) don't mistake "requester_ua" for per-page
+// visitor/bot data. Capturing the User-Agent/Server headers a scraped page
+// actually returned would need the external Firecrawl service to report
+// them back — e.g. via a webhook payload this backend doesn't model today
+// — and that prerequisite, not this endpoint, is what's missing.
+const extractionAgentsCaveat = "ua classifications reflect the User-Agent of whoever called this API to start the batch, not the bots/browsers that visited the scraped pages; per-page visitor classification requires the external Firecrawl service to report each page's response headers back to this backend, which isn't implemented"
+
+// GetExtractionAgents returns the services.UAClassifier classification
+// recorded for a session (currently the requester's own User-Agent at the
+// time of StartBatchExtraction, copied identically onto every URL created
+// by that call — see its doc comment for why) alongside the list of URLs
+// the session extracted.
+//
+// That classification is necessarily one value per session, not one per
+// extraction, so it's surfaced once as requester_classification rather
+// than bucketed into bot_counts/category_counts the way an earlier version
+// of this endpoint did: every row in a session shares the identical
+// classification, so a per-extraction count distribution would just
+// restate the session's URL count under whatever bucket the one shared
+// classification happens to fall into, implying a diversity of callers
+// that was never actually observed.
+func (h *SitemapHandler) GetExtractionAgents(c *gin.Context) {
+	sessionID := c.Param("sessionId")
+	if sessionID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "session_id is required"})
+		return
+	}
+
+	var extractions []models.URLExtraction
+	if err := h.db.DB.Where("session_id = ?", sessionID).Find(&extractions).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "Failed to fetch extraction agents",
+			"details": err.Error(),
+		})
+		return
+	}
+	if len(extractions) == 0 {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Extraction session not found or has no URLs"})
+		return
+	}
+
+	type urlEntry struct {
+		ExtractionID string `json:"extraction_id"`
+		URL          string `json:"url"`
+	}
+
+	urls := make([]urlEntry, 0, len(extractions))
+	var classification services.Classification
+	var classified bool
+
+	for _, extraction := range extractions {
+		urls = append(urls, urlEntry{ExtractionID: extraction.ExtractionID, URL: extraction.URL})
+
+		if classified {
+			continue
+		}
+		if raw, ok := extraction.Metadata["requester_ua"]; ok {
+			if c, ok := decodeUAClassification(raw); ok {
+				classification = c
+				classified = true
+			}
+		}
+	}
+
+	data := gin.H{
+		"session_id": sessionID,
+		"urls":       urls,
+		"caveat":     extractionAgentsCaveat,
+	}
+	if classified {
+		data["requester_classification"] = classification
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    data,
+	})
+}
+
+// decodeUAClassification recovers a services.Classification from a
+// URLExtraction's jsonb Metadata, which GORM round-trips through
+// interface{} (a map[string]interface{} after JSON decode, not the
+// original struct), so it's re-marshalled and unmarshalled rather than
+// type-asserted directly.
+func decodeUAClassification(raw interface{}) (services.Classification, bool) {
+	var classification services.Classification
+	encoded, err := json.Marshal(raw)
+	if err != nil {
+		return classification, false
+	}
+	if err := json.Unmarshal(encoded, &classification); err != nil {
+		return classification, false
+	}
+	return classification, true
+}
+
+// StreamExtractionProgress streams live progress for an extraction session as
+// Server-Sent Events. It replaces polling GetExtractionProgress for clients
+// that want to watch a long-running batch without hammering Postgres: the
+// stream opens with the current DB snapshot, then forwards whatever the
+// session's ProgressHub publishes until the client disconnects or the
+// session completes.
+func (h *SitemapHandler) StreamExtractionProgress(c *gin.Context) {
+	sessionID := c.Param("sessionId")
+	if sessionID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "session_id is required",
+		})
+		return
+	}
+
+	var session models.ExtractionSession
+	if err := h.db.DB.Where("session_id = ?", sessionID).First(&session).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": "Extraction session not found",
+		})
+		return
+	}
+
+	events, unsubscribe := h.progressHub.Subscribe(sessionID)
+	defer unsubscribe()
+
+	c.Header("Content-Type", "text/event-stream")
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Connection", "keep-alive")
+	c.Header("X-Accel-Buffering", "no")
+
+	// Flush the current DB state immediately so a freshly connected client
+	// doesn't have to wait for the next mutation to know where things stand.
+	h.writeSSEEvent(c, services.ExtractionEvent{
+		Type:      services.ExtractionEventProgress,
+		SessionID: sessionID,
+		Data: gin.H{
+			"total_urls":      session.TotalURLs,
+			"successful_urls": session.SuccessfulURLs,
+			"failed_urls":     session.FailedURLs,
+			"status":          session.Status,
+		},
+	})
+	c.Writer.Flush()
+
+	if session.Status != models.ExtractionStatusInProgress {
+		h.writeSSEEvent(c, services.ExtractionEvent{
+			Type:      services.ExtractionEventSessionCompleted,
+			SessionID: sessionID,
+			Data:      gin.H{"status": session.Status},
+		})
+		c.Writer.Flush()
+		return
+	}
+
+	ctx := c.Request.Context()
+	heartbeat := time.NewTicker(15 * time.Second)
+	defer heartbeat.Stop()
+
+	// There's no in-process worker pushing per-URL callbacks (extraction
+	// itself happens in the external firecrawl service), so a DB poller is
+	// what actually turns DB writes into stream events. One shared poller
+	// per session (started here, stopped once the hub has no subscribers
+	// left) feeds every connection's events channel, instead of each
+	// connection running its own ticker against Postgres.
+	h.ensureSessionPoller(sessionID, session)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-heartbeat.C:
+			fmt.Fprint(c.Writer, ": heartbeat\n\n")
+			c.Writer.Flush()
+		case event, ok := <-events:
+			if !ok {
+				return
+			}
+			h.writeSSEEvent(c, event)
+			c.Writer.Flush()
+			if event.Type == services.ExtractionEventSessionCompleted {
+				return
+			}
+		}
+	}
+}
+
+// sessionPollInterval is how often runSessionPoller re-reads a session's
+// row while it has at least one StreamExtractionProgress subscriber.
+const sessionPollInterval = 3 * time.Second
+
+// ensureSessionPoller starts runSessionPoller for sessionID if one isn't
+// already running. Safe to call from every StreamExtractionProgress
+// connection for the same session; only the first actually starts a
+// goroutine.
+func (h *SitemapHandler) ensureSessionPoller(sessionID string, session models.ExtractionSession) {
+	h.sessionPollersMu.Lock()
+	defer h.sessionPollersMu.Unlock()
+
+	if _, running := h.sessionPollers[sessionID]; running {
+		return
+	}
+	h.sessionPollers[sessionID] = struct{}{}
+	go h.runSessionPoller(sessionID, session.SuccessfulURLs, session.FailedURLs, session.Status)
+}
+
+// runSessionPoller re-reads sessionID's row every sessionPollInterval,
+// republishing any successful/failed/status delta to every
+// StreamExtractionProgress connection subscribed to the session via
+// progressHub, and exits once the hub reports no subscribers left (or the
+// session reaches a terminal status).
+func (h *SitemapHandler) runSessionPoller(sessionID string, lastSuccess, lastFailed int, lastStatus models.ExtractionStatus) {
+	defer func() {
+		h.sessionPollersMu.Lock()
+		delete(h.sessionPollers, sessionID)
+		h.sessionPollersMu.Unlock()
+	}()
+
+	ticker := time.NewTicker(sessionPollInterval)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		if !h.progressHub.HasSubscribers(sessionID) {
+			return
+		}
+
+		var current models.ExtractionSession
+		if err := h.db.DB.Where("session_id = ?", sessionID).First(&current).Error; err != nil {
+			continue
+		}
+
+		if current.SuccessfulURLs != lastSuccess || current.FailedURLs != lastFailed || current.Status != lastStatus {
+			if current.FailedURLs > lastFailed {
+				for i := 0; i < current.FailedURLs-lastFailed; i++ {
+					metrics.ObserveURLStatus(string(models.ExtractionURLStatusFailed))
+				}
+				h.progressHub.Publish(sessionID, services.ExtractionEvent{
+					Type: services.ExtractionEventURLFailed,
+					Data: gin.H{"failed_urls": current.FailedURLs},
+				})
+			}
+			if current.SuccessfulURLs > lastSuccess {
+				for i := 0; i < current.SuccessfulURLs-lastSuccess; i++ {
+					metrics.ObserveURLStatus(string(models.ExtractionURLStatusSuccess))
+				}
+				h.progressHub.Publish(sessionID, services.ExtractionEvent{
+					Type: services.ExtractionEventURLCompleted,
+					Data: gin.H{"successful_urls": current.SuccessfulURLs},
+				})
+			}
+			h.progressHub.Publish(sessionID, services.ExtractionEvent{
+				Type: services.ExtractionEventProgress,
+				Data: gin.H{
+					"total_urls":      current.TotalURLs,
+					"successful_urls": current.SuccessfulURLs,
+					"failed_urls":     current.FailedURLs,
+					"status":          current.Status,
+				},
+			})
+			lastSuccess, lastFailed, lastStatus = current.SuccessfulURLs, current.FailedURLs, current.Status
+		}
+
+		if current.Status != models.ExtractionStatusInProgress && current.Status != models.ExtractionStatusPaused {
+			h.progressHub.Publish(sessionID, services.ExtractionEvent{
+				Type: services.ExtractionEventSessionCompleted,
+				Data: gin.H{"status": current.Status},
+			})
+			return
+		}
+	}
+}
+
+// writeSSEEvent writes a single named SSE event with a JSON data payload.
+func (h *SitemapHandler) writeSSEEvent(c *gin.Context, event services.ExtractionEvent) {
+	payload, err := json.Marshal(event.Data)
+	if err != nil {
+		return
 	}
-	return strconv.Itoa(hash)
+	fmt.Fprintf(c.Writer, "event: %s\ndata: %s\n\n", event.Type, payload)
+}
+
+// generateURLHash computes the content-addressable SHA-256 hash used for
+// cross-session deduplication. See internal/urlhash for the canonicalization
+// rules (tracking params stripped, query sorted, default ports dropped).
+func generateURLHash(url string) string {
+	return urlhash.Hash(url)
 }
 
 // Helper function to calculate progress percentage
@@ -473,3 +1129,188 @@ func calculateProgressPercent(processed, total int) float64 {
 	}
 	return float64(processed) / float64(total) * 100
 }
+
+// PauseExtraction checkpoints an in-progress session and marks it paused so
+// workers can drain gracefully. The checkpoint records the furthest
+// chunk/position confirmed complete plus any URLs still mid-flight, which
+// ResumeExtraction (or a crash-recovery scan) uses to pick up exactly where
+// the session left off.
+func (h *SitemapHandler) PauseExtraction(c *gin.Context) {
+	sessionID := c.Param("sessionId")
+	if sessionID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "session_id is required"})
+		return
+	}
+
+	var session models.ExtractionSession
+	if err := h.db.DB.Where("session_id = ?", sessionID).First(&session).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Extraction session not found"})
+		return
+	}
+
+	if session.Status != models.ExtractionStatusInProgress {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Only in-progress extractions can be paused"})
+		return
+	}
+
+	checkpoint, err := h.writeCheckpoint(sessionID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "Failed to checkpoint session",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	if err := h.db.DB.Model(&session).Update("status", models.ExtractionStatusPaused).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "Failed to update session status",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	h.progressHub.Publish(sessionID, services.ExtractionEvent{
+		Type: services.ExtractionEventStatusChanged,
+		Data: gin.H{"status": models.ExtractionStatusPaused},
+	})
+
+	c.JSON(http.StatusOK, gin.H{
+		"success":    true,
+		"message":    "Extraction paused",
+		"checkpoint": checkpoint,
+	})
+}
+
+// ResumeExtraction resumes a paused session from its last checkpoint. Any
+// URLExtraction rows left in "processing" (from before the pause) are
+// requeued to "pending" so they get picked up again rather than stranded.
+func (h *SitemapHandler) ResumeExtraction(c *gin.Context) {
+	sessionID := c.Param("sessionId")
+	if sessionID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "session_id is required"})
+		return
+	}
+
+	var session models.ExtractionSession
+	if err := h.db.DB.Where("session_id = ?", sessionID).First(&session).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Extraction session not found"})
+		return
+	}
+
+	if session.Status != models.ExtractionStatusPaused {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Only paused extractions can be resumed"})
+		return
+	}
+
+	if err := requeueInFlight(h.db, sessionID); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "Failed to requeue in-flight URLs",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	if err := h.db.DB.Model(&session).Update("status", models.ExtractionStatusInProgress).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "Failed to update session status",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	h.progressHub.Publish(sessionID, services.ExtractionEvent{
+		Type: services.ExtractionEventStatusChanged,
+		Data: gin.H{"status": models.ExtractionStatusInProgress},
+	})
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"message": "Extraction resumed",
+	})
+}
+
+// writeCheckpoint upserts the ExtractionCheckpoint for a session based on its
+// current URLExtraction rows: the furthest chunk/position whose URL
+// succeeded, plus every URL still mid-flight.
+func (h *SitemapHandler) writeCheckpoint(sessionID string) (*models.ExtractionCheckpoint, error) {
+	var lastSuccess models.URLExtraction
+	hasProgress := true
+	err := h.db.DB.
+		Where("session_id = ? AND status = ?", sessionID, models.ExtractionURLStatusSuccess).
+		Order("chunk_number DESC, position_in_chunk DESC").
+		First(&lastSuccess).Error
+	if err != nil {
+		hasProgress = false
+	}
+
+	var inFlight []models.URLExtraction
+	if err := h.db.DB.
+		Where("session_id = ? AND status = ?", sessionID, models.ExtractionURLStatusProcessing).
+		Find(&inFlight).Error; err != nil {
+		return nil, err
+	}
+
+	inFlightURLs := make([]string, len(inFlight))
+	for i, ue := range inFlight {
+		inFlightURLs[i] = ue.URL
+	}
+
+	checkpoint := models.ExtractionCheckpoint{
+		SessionID:      sessionID,
+		InFlightURLs:   inFlightURLs,
+		CheckpointedAt: time.Now(),
+	}
+	if hasProgress {
+		checkpoint.LastChunkNumber = lastSuccess.ChunkNumber
+		checkpoint.LastPositionInChunk = lastSuccess.PositionInChunk
+	}
+
+	var existing models.ExtractionCheckpoint
+	if err := h.db.DB.Where("session_id = ?", sessionID).First(&existing).Error; err == nil {
+		checkpoint.ID = existing.ID
+		if err := h.db.DB.Save(&checkpoint).Error; err != nil {
+			return nil, err
+		}
+		return &checkpoint, nil
+	}
+
+	if err := h.db.DB.Create(&checkpoint).Error; err != nil {
+		return nil, err
+	}
+	return &checkpoint, nil
+}
+
+// requeueInFlight resets any URLExtraction still marked "processing" for a
+// session back to "pending", bumping its attempt count since the previous
+// attempt never reported a result.
+func requeueInFlight(db *storage.Database, sessionID string) error {
+	return db.DB.Model(&models.URLExtraction{}).
+		Where("session_id = ? AND status = ?", sessionID, models.ExtractionURLStatusProcessing).
+		Updates(map[string]interface{}{
+			"status":        models.ExtractionURLStatusPending,
+			"attempt_count": gorm.Expr("attempt_count + 1"),
+		}).Error
+}
+
+// RecoverStaleSessions scans for sessions left "in_progress" by a process
+// that died mid-extraction (detected via a stale UpdatedAt) and requeues
+// their in-flight URLs so a freshly started server picks up where the last
+// one crashed, instead of those URLs being stuck forever. It's intended to
+// run once at startup, from api.NewRouter.
+func RecoverStaleSessions(db *storage.Database, staleAfter time.Duration) error {
+	var stale []models.ExtractionSession
+	cutoff := time.Now().Add(-staleAfter)
+	if err := db.DB.
+		Where("status = ? AND updated_at < ?", models.ExtractionStatusInProgress, cutoff).
+		Find(&stale).Error; err != nil {
+		return err
+	}
+
+	for _, session := range stale {
+		if err := requeueInFlight(db, session.SessionID); err != nil {
+			return fmt.Errorf("recover session %s: %w", session.SessionID, err)
+		}
+	}
+	return nil
+}
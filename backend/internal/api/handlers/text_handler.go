@@ -1,25 +1,99 @@
 package handlers
 
 import (
-	"math"
+	"context"
+	"log"
 	"net/http"
-	"regexp"
+	"sort"
 	"strconv"
-	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
 
+	"github.com/bartosz/stocks-out-for-harambe/backend/internal/audit"
+	"github.com/bartosz/stocks-out-for-harambe/backend/internal/filtering"
+	"github.com/bartosz/stocks-out-for-harambe/backend/internal/jobs"
 	"github.com/bartosz/stocks-out-for-harambe/backend/internal/models"
+	"github.com/bartosz/stocks-out-for-harambe/backend/internal/nlp"
+	"github.com/bartosz/stocks-out-for-harambe/backend/internal/progress"
+	"github.com/bartosz/stocks-out-for-harambe/backend/internal/search"
 	"github.com/bartosz/stocks-out-for-harambe/backend/internal/storage"
+	"github.com/bartosz/stocks-out-for-harambe/backend/internal/textanalysis/extract"
+	"github.com/bartosz/stocks-out-for-harambe/backend/internal/translation"
 	"github.com/gin-gonic/gin"
 )
 
+// batchAnalyzeTimeout bounds how long a single BatchAnalyzeText job may run
+// before the job manager cancels it, regardless of whether the submitting
+// request's own context is still live.
+const batchAnalyzeTimeout = 2 * time.Minute
+
 // TextHandler handles text analysis API endpoints
 type TextHandler struct {
-	db *storage.Database
+	db                    *storage.Database
+	jobManager            *jobs.Manager
+	index                 search.Index
+	nlpProvider           nlp.Provider
+	nlpConcurrency        int
+	translationProvider   translation.Provider
+	translationTargetLang string
+	progressBroker        *progress.Broker
+	filterEngine          *filtering.Engine
+}
+
+// NewTextHandler creates a new text handler. nlpConcurrency bounds how many
+// texts BatchAnalyzeText sends to nlpProvider at once, analogous to
+// FirecrawlConfig.ConcurrentJobs. translationProvider may be nil, meaning
+// translation enrichment is disabled. progressBroker receives a
+// "text_completed" event per finished text during BatchAnalyzeText, keyed by
+// job ID, for JobsHandler.StreamJobEvents to forward to SSE clients.
+// filterEngine matches each analysis's text, keywords, and entities against
+// the active models.Filter set before persistence.
+func NewTextHandler(db *storage.Database, jobManager *jobs.Manager, index search.Index, nlpProvider nlp.Provider, nlpConcurrency int, translationProvider translation.Provider, translationTargetLang string, progressBroker *progress.Broker, filterEngine *filtering.Engine) *TextHandler {
+	if nlpConcurrency <= 0 {
+		nlpConcurrency = 1
+	}
+	return &TextHandler{
+		db:                    db,
+		jobManager:            jobManager,
+		index:                 index,
+		nlpProvider:           nlpProvider,
+		nlpConcurrency:        nlpConcurrency,
+		translationProvider:   translationProvider,
+		translationTargetLang: translationTargetLang,
+		progressBroker:        progressBroker,
+		filterEngine:          filterEngine,
+	}
 }
 
-// NewTextHandler creates a new text handler
-func NewTextHandler(db *storage.Database) *TextHandler {
-	return &TextHandler{db: db}
+// applyFilters matches analysis's text against the "analysis" context, its
+// keywords against "keyword", and its entities against "entity". It reports
+// dropped=true when an irreversible filter matched anywhere, in which case
+// the caller must not persist analysis at all; otherwise any matches are
+// recorded on analysis.FilteredBy for the UI to hide/collapse.
+func (h *TextHandler) applyFilters(analysis *models.TextAnalysis) (dropped bool) {
+	if h.filterEngine == nil {
+		return false
+	}
+
+	var matches []models.Filter
+	matches = append(matches, h.filterEngine.Match(models.FilterContextAnalysis, analysis.Text)...)
+	for _, keyword := range analysis.Keywords {
+		matches = append(matches, h.filterEngine.Match(models.FilterContextKeyword, keyword.Word)...)
+	}
+	for _, entity := range analysis.Entities {
+		matches = append(matches, h.filterEngine.Match(models.FilterContextEntity, entity.Text)...)
+	}
+
+	if len(matches) == 0 {
+		return false
+	}
+	if filtering.Irreversible(matches) {
+		return true
+	}
+
+	analysis.FilteredBy = filtering.Names(matches)
+	return false
 }
 
 // AnalyzeText analyzes a single text
@@ -30,11 +104,16 @@ func (h *TextHandler) AnalyzeText(c *gin.Context) {
 		return
 	}
 
-	// Perform text analysis
-	analysis := h.performTextAnalysis(req.Text, req.Language)
+	// Perform text analysis, translating first if needed
+	analysis, translationResult := h.analyzeWithTranslation(c.Request.Context(), req.Text, req.Language, req.KeywordMode, req.Translate)
 	analysis.Metadata = req.Metadata
 	analysis.IsPublic = req.IsPublic
 
+	if h.applyFilters(&analysis) {
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"error": "Text matched an irreversible filter and was not saved"})
+		return
+	}
+
 	// Save to database
 	if err := h.db.Create(&analysis).Error; err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to save analysis"})
@@ -44,10 +123,23 @@ func (h *TextHandler) AnalyzeText(c *gin.Context) {
 	// Load relationships for response
 	h.db.Preload("Keywords").Preload("Entities").First(&analysis, analysis.ID)
 
+	audit.Record(c.Request.Context(), "analyze", "text_analysis", analysis.ID, nil, analysis)
+
+	if translationResult != nil {
+		h.saveTranslation(analysis.ID, analysis.Language, translationResult)
+	}
+
+	if err := h.index.IndexAnalysis(c.Request.Context(), &analysis); err != nil {
+		log.Printf("Warning: failed to index analysis %d: %v", analysis.ID, err)
+	}
+
 	c.JSON(http.StatusCreated, analysis.ToResponse())
 }
 
-// BatchAnalyzeText analyzes multiple texts
+// BatchAnalyzeText analyzes multiple texts. The batch runs as a cancellable
+// job on h.jobManager, so a client that hangs up mid-request — or an admin
+// issuing DELETE /api/v1/jobs/{id} — stops the batch promptly instead of it
+// running to completion regardless.
 func (h *TextHandler) BatchAnalyzeText(c *gin.Context) {
 	var req models.BatchAnalyzeTextRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
@@ -55,32 +147,122 @@ func (h *TextHandler) BatchAnalyzeText(c *gin.Context) {
 		return
 	}
 
-	var results []models.TextAnalysisResponse
-	var analyses []models.TextAnalysis
+	jobID, resultCh := h.jobManager.Submit(c.Request.Context(), jobs.Spec{
+		Kind:    "batch_analyze",
+		Timeout: batchAnalyzeTimeout,
+		Work: func(ctx context.Context) (interface{}, error) {
+			return h.runBatchAnalysis(ctx, req)
+		},
+	})
+
+	result := <-resultCh
+	if result.Err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": result.Err.Error(), "job_id": jobID})
+		return
+	}
+
+	results := result.Value.([]models.TextAnalysisResponse)
+	c.JSON(http.StatusCreated, gin.H{
+		"analyses": results,
+		"count":    len(results),
+		"job_id":   jobID,
+	})
+}
+
+// runBatchAnalysis performs the actual per-text analysis and DB writes for
+// BatchAnalyzeText. Analyses fan out across a pool of h.nlpConcurrency
+// workers, since each one is an independent call to h.nlpProvider (possibly
+// a network round trip for HTTPProvider/GRPCProvider) rather than a shared
+// resource that needs serializing.
+func (h *TextHandler) runBatchAnalysis(ctx context.Context, req models.BatchAnalyzeTextRequest) ([]models.TextAnalysisResponse, error) {
+	analyses := make([]models.TextAnalysis, len(req.Texts))
+	translations := make([]*translation.Result, len(req.Texts))
+	dropped := make([]bool, len(req.Texts))
+
+	// jobID is "" if runBatchAnalysis is ever called outside a jobs.Manager
+	// job (it currently isn't), in which case the progress publishes below
+	// are skipped.
+	jobID := jobs.JobIDFromContext(ctx)
+	total := len(req.Texts)
+	var completed int32
+
+	sem := make(chan struct{}, h.nlpConcurrency)
+	var wg sync.WaitGroup
+	for i, textReq := range req.Texts {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(i int, textReq models.AnalyzeTextRequest) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			analysis, translationResult := h.analyzeWithTranslation(ctx, textReq.Text, textReq.Language, textReq.KeywordMode, textReq.Translate)
+			analysis.Metadata = textReq.Metadata
+			analysis.IsPublic = textReq.IsPublic
+
+			if h.applyFilters(&analysis) {
+				dropped[i] = true
+			} else {
+				analyses[i] = analysis
+				translations[i] = translationResult
+			}
+
+			if h.progressBroker != nil && jobID != "" {
+				done := atomic.AddInt32(&completed, 1)
+				h.progressBroker.Publish(jobID, "text_completed", gin.H{
+					"completed": int(done),
+					"total":     total,
+				})
+			}
+		}(i, textReq)
+	}
+	wg.Wait()
 
-	for _, textReq := range req.Texts {
-		analysis := h.performTextAnalysis(textReq.Text, textReq.Language)
-		analysis.Metadata = textReq.Metadata
-		analysis.IsPublic = textReq.IsPublic
-		analyses = append(analyses, analysis)
+	if err := ctx.Err(); err != nil {
+		return nil, err
 	}
 
+	// Drop texts that matched an irreversible filter before they're ever
+	// persisted; kept in index-parallel order with translations.
+	keptAnalyses := analyses[:0]
+	keptTranslations := translations[:0]
+	for i, wasDropped := range dropped {
+		if wasDropped {
+			continue
+		}
+		keptAnalyses = append(keptAnalyses, analyses[i])
+		keptTranslations = append(keptTranslations, translations[i])
+	}
+	analyses = keptAnalyses
+	translations = keptTranslations
+
 	// Bulk create
 	if err := h.db.Create(&analyses).Error; err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to save analyses"})
-		return
+		return nil, err
+	}
+
+	for i, translationResult := range translations {
+		if translationResult != nil {
+			h.saveTranslation(analyses[i].ID, analyses[i].Language, translationResult)
+		}
+	}
+
+	if err := h.index.BulkIndex(ctx, analyses); err != nil {
+		log.Printf("Warning: failed to bulk index %d analyses: %v", len(analyses), err)
+	}
+
+	if h.progressBroker != nil && jobID != "" {
+		h.progressBroker.Publish(jobID, "job_completed", gin.H{"completed": total, "total": total})
 	}
 
 	// Convert to response format
+	var results []models.TextAnalysisResponse
 	for _, analysis := range analyses {
 		h.db.Preload("Keywords").Preload("Entities").First(&analysis, analysis.ID)
+		audit.Record(ctx, "analyze", "text_analysis", analysis.ID, nil, analysis)
 		results = append(results, analysis.ToResponse())
 	}
 
-	c.JSON(http.StatusCreated, gin.H{
-		"analyses": results,
-		"count":    len(results),
-	})
+	return results, nil
 }
 
 // ListAnalyses returns all text analyses
@@ -139,6 +321,12 @@ func (h *TextHandler) GetAnalysis(c *gin.Context) {
 func (h *TextHandler) DeleteAnalysis(c *gin.Context) {
 	id := c.Param("id")
 
+	var analysis models.TextAnalysis
+	if err := h.db.First(&analysis, id).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Analysis not found"})
+		return
+	}
+
 	// Delete keywords and entities first
 	h.db.Where("analysis_id = ?", id).Delete(&models.Keyword{})
 	h.db.Where("analysis_id = ?", id).Delete(&models.Entity{})
@@ -149,20 +337,19 @@ func (h *TextHandler) DeleteAnalysis(c *gin.Context) {
 		return
 	}
 
+	audit.Record(c.Request.Context(), "delete", "text_analysis", analysis.ID, analysis, nil)
+
 	c.JSON(http.StatusOK, gin.H{"message": "Analysis deleted successfully"})
 }
 
-// GetPopularKeywords returns most frequent keywords
+// GetPopularKeywords returns most frequent keywords, via h.index so this
+// scales to an Elasticsearch terms aggregation once SQLIndex's group-by
+// stops keeping up.
 func (h *TextHandler) GetPopularKeywords(c *gin.Context) {
 	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "50"))
 
-	var keywords []models.Keyword
-	if err := h.db.
-		Select("word, SUM(frequency) as frequency, AVG(relevance) as relevance").
-		Group("word").
-		Order("frequency DESC").
-		Limit(limit).
-		Find(&keywords).Error; err != nil {
+	keywords, err := h.index.KeywordAggregations(c.Request.Context(), limit)
+	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch keywords"})
 		return
 	}
@@ -170,34 +357,68 @@ func (h *TextHandler) GetPopularKeywords(c *gin.Context) {
 	c.JSON(http.StatusOK, gin.H{"keywords": keywords})
 }
 
-// GetPopularEntities returns most frequent entities
+// GetPopularEntities returns most frequent entities, optionally filtered by
+// type, via h.index.
 func (h *TextHandler) GetPopularEntities(c *gin.Context) {
 	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "50"))
 	entityType := c.Query("type")
 
-	query := h.db.
-		Select("text, type, COUNT(*) as frequency, AVG(confidence) as confidence").
-		Group("text, type").
-		Order("frequency DESC").
-		Limit(limit)
-
-	if entityType != "" {
-		query = query.Where("type = ?", entityType)
+	entities, err := h.index.EntityAggregations(c.Request.Context(), entityType, limit)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch entities"})
+		return
 	}
 
-	var entities []struct {
-		Text       string  `json:"text"`
-		Type       string  `json:"type"`
-		Frequency  int     `json:"frequency"`
-		Confidence float64 `json:"confidence"`
+	c.JSON(http.StatusOK, gin.H{"entities": entities})
+}
+
+// SearchText performs full-text search over indexed analyses via
+// GET /api/v1/text/search?q=...&language=...&sentiment=...&page=...&limit=...
+func (h *TextHandler) SearchText(c *gin.Context) {
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "20"))
+
+	results, err := h.index.Search(c.Request.Context(), search.Query{
+		Text:      c.Query("q"),
+		Language:  c.Query("language"),
+		Sentiment: c.Query("sentiment"),
+		Page:      page,
+		Limit:     limit,
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to search analyses"})
+		return
 	}
 
-	if err := query.Find(&entities).Error; err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch entities"})
+	c.JSON(http.StatusOK, gin.H{"results": results, "page": page, "limit": limit})
+}
+
+// GetSentimentTrend buckets average sentiment score over time via
+// GET /api/v1/text/sentiment-trend?bucket=day&limit=30
+func (h *TextHandler) GetSentimentTrend(c *gin.Context) {
+	bucket := parseBucketDuration(c.DefaultQuery("bucket", "day"))
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "30"))
+
+	buckets, err := h.index.SentimentTrend(c.Request.Context(), bucket, limit)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to compute sentiment trend"})
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{"entities": entities})
+	c.JSON(http.StatusOK, gin.H{"trend": buckets})
+}
+
+// parseBucketDuration maps a bucket query parameter ("hour", "day", "week")
+// to the Duration SentimentTrend expects, defaulting to a day.
+func parseBucketDuration(bucket string) time.Duration {
+	switch bucket {
+	case "hour":
+		return time.Hour
+	case "week":
+		return 7 * 24 * time.Hour
+	default:
+		return 24 * time.Hour
+	}
 }
 
 // GetSampleTexts returns sample texts for development/testing
@@ -228,275 +449,262 @@ func (h *TextHandler) GetSampleTexts(c *gin.Context) {
 	c.JSON(http.StatusOK, gin.H{"samples": samples})
 }
 
-// performTextAnalysis performs the actual text analysis
-func (h *TextHandler) performTextAnalysis(text, language string) models.TextAnalysis {
-	if language == "" {
-		language = "en"
-	}
+// analyzeWithTranslation performs text analysis, translating text into
+// h.translationTargetLang first when its language doesn't already match (or
+// forceTranslate is set) and re-running sentiment/keyword/entity/
+// readability analysis against the translated text, so English-tuned
+// lexicons still work on non-English input. Returns the translation result
+// alongside the analysis so the caller can persist it as a Translation
+// child row once the analysis itself has an ID.
+func (h *TextHandler) analyzeWithTranslation(ctx context.Context, text, language, keywordMode string, forceTranslate bool) (models.TextAnalysis, *translation.Result) {
+	analysis := h.performTextAnalysis(ctx, text, language, keywordMode)
 
-	analysis := models.TextAnalysis{
-		Text:     text,
-		Language: language,
+	translationResult := h.maybeTranslate(ctx, text, analysis.Language, forceTranslate)
+	if translationResult == nil {
+		return analysis, nil
 	}
 
-	// Analyze sentiment
-	analysis.Sentiment = h.analyzeSentiment(text)
+	reanalysis := h.performTextAnalysis(ctx, translationResult.TranslatedText, h.translationTargetLang, keywordMode)
+	analysis.Sentiment = reanalysis.Sentiment
+	analysis.Keywords = reanalysis.Keywords
+	analysis.Entities = reanalysis.Entities
+	analysis.Readability = reanalysis.Readability
 
-	// Extract keywords
-	keywords := h.extractKeywords(text)
-	for _, keyword := range keywords {
-		analysis.Keywords = append(analysis.Keywords, models.Keyword{
-			Word:      keyword.Word,
-			Frequency: keyword.Frequency,
-			Relevance: keyword.Relevance,
-		})
-	}
+	return analysis, translationResult
+}
 
-	// Extract entities
-	entities := h.extractEntities(text)
-	for _, entity := range entities {
-		analysis.Entities = append(analysis.Entities, models.Entity{
-			Text:       entity.Text,
-			Type:       entity.Type,
-			Confidence: entity.Confidence,
-		})
+// maybeTranslate runs h.translationProvider against text when translation
+// is configured and either forceTranslate is set or language doesn't
+// already match h.translationTargetLang. Returns nil when translation is
+// disabled, not needed, or fails (logged as a warning, not fatal to the
+// surrounding analysis).
+func (h *TextHandler) maybeTranslate(ctx context.Context, text, language string, forceTranslate bool) *translation.Result {
+	if h.translationProvider == nil {
+		return nil
+	}
+	if !forceTranslate && (h.translationTargetLang == "" || language == h.translationTargetLang) {
+		return nil
 	}
 
-	// Calculate readability
-	analysis.Readability = h.calculateReadability(text)
-
-	return analysis
+	result, err := h.translationProvider.Translate(ctx, text, language, h.translationTargetLang)
+	if err != nil {
+		log.Printf("Warning: translation failed for language %q: %v", language, err)
+		return nil
+	}
+	return &result
 }
 
-// analyzeSentiment performs sentiment analysis
-func (h *TextHandler) analyzeSentiment(text string) models.SentimentAnalysis {
-	positiveWords := []string{
-		"good", "great", "excellent", "amazing", "wonderful", "fantastic", "awesome",
-		"love", "like", "enjoy", "happy", "pleased", "satisfied", "perfect",
-		"brilliant", "outstanding", "superb", "magnificent", "impressive",
-		"positive", "success", "successful", "achievement", "accomplish",
-		"effective", "efficient", "valuable", "helpful", "useful",
+// saveTranslation persists result as a Translation child row of analysisID.
+func (h *TextHandler) saveTranslation(analysisID uint, sourceLang string, result *translation.Result) {
+	senses := make([]models.Sense, len(result.Senses))
+	for i, s := range result.Senses {
+		senses[i] = models.Sense{PartOfSpeech: s.PartOfSpeech, Phonetic: s.Phonetic, Definition: s.Definition, Examples: s.Examples}
 	}
 
-	negativeWords := []string{
-		"bad", "terrible", "awful", "horrible", "disgusting", "hate", "dislike",
-		"angry", "frustrated", "disappointed", "upset", "sad", "depressed",
-		"poor", "weak", "fail", "failure", "problem", "issue", "difficult",
-		"impossible", "useless", "worthless", "waste", "expensive",
-		"slow", "broken", "error", "bug", "wrong", "incorrect",
+	tr := models.Translation{
+		AnalysisID:     analysisID,
+		SourceLang:     sourceLang,
+		TargetLang:     h.translationTargetLang,
+		TranslatedText: result.TranslatedText,
+		Senses:         senses,
 	}
-
-	words := regexp.MustCompile(`\b\w+\b`).FindAllString(strings.ToLower(text), -1)
-
-	positiveCount := 0
-	negativeCount := 0
-
-	for _, word := range words {
-		for _, pos := range positiveWords {
-			if word == pos {
-				positiveCount++
-				break
-			}
-		}
-		for _, neg := range negativeWords {
-			if word == neg {
-				negativeCount++
-				break
-			}
-		}
+	if err := h.db.Create(&tr).Error; err != nil {
+		log.Printf("Warning: failed to save translation for analysis %d: %v", analysisID, err)
 	}
+}
 
-	totalSentimentWords := positiveCount + negativeCount
+// GetTranslation returns the stored Translation for an analysis into target
+// (defaulting to h.translationTargetLang), translating on demand via
+// h.translationProvider if one wasn't already persisted — e.g. the analysis
+// was created without Translate=true, or a caller now wants a different
+// target language than the one AnalyzeText enriched it with.
+func (h *TextHandler) GetTranslation(c *gin.Context) {
+	id := c.Param("id")
+	target := c.DefaultQuery("target", h.translationTargetLang)
 
-	if totalSentimentWords == 0 {
-		return models.SentimentAnalysis{
-			Score:      0,
-			Label:      "neutral",
-			Confidence: 0.5,
-		}
+	var analysis models.TextAnalysis
+	if err := h.db.First(&analysis, id).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Analysis not found"})
+		return
 	}
 
-	score := float64(positiveCount-negativeCount) / float64(totalSentimentWords)
-	confidence := math.Min(0.9, math.Max(0.1, float64(totalSentimentWords)/float64(len(words))*5))
-
-	label := "neutral"
-	if score > 0.1 {
-		label = "positive"
-	} else if score < -0.1 {
-		label = "negative"
+	var tr models.Translation
+	err := h.db.Where("analysis_id = ? AND target_lang = ?", analysis.ID, target).First(&tr).Error
+	if err == nil {
+		c.JSON(http.StatusOK, tr)
+		return
 	}
 
-	return models.SentimentAnalysis{
-		Score:      math.Round(score*1000) / 1000,
-		Label:      label,
-		Confidence: math.Round(confidence*1000) / 1000,
+	if h.translationProvider == nil {
+		c.JSON(http.StatusServiceUnavailable, gin.H{"error": "translation provider not configured"})
+		return
 	}
-}
 
-// extractKeywords extracts keywords from text
-func (h *TextHandler) extractKeywords(text string) []models.KeywordResponse {
-	stopWords := map[string]bool{
-		"the": true, "a": true, "an": true, "and": true, "or": true, "but": true,
-		"in": true, "on": true, "at": true, "to": true, "for": true, "of": true, "with": true,
-		"by": true, "from": true, "up": true, "about": true, "into": true, "through": true,
-		"during": true, "before": true, "after": true, "above": true, "below": true,
-		"is": true, "are": true, "was": true, "were": true, "be": true, "been": true, "being": true,
-		"have": true, "has": true, "had": true, "do": true, "does": true, "did": true,
-		"this": true, "that": true, "these": true, "those": true, "i": true, "you": true,
-		"he": true, "she": true, "it": true, "we": true, "they": true,
-	}
-
-	words := regexp.MustCompile(`\b\w{3,}\b`).FindAllString(strings.ToLower(text), -1)
-	wordCount := make(map[string]int)
-	totalWords := len(words)
-
-	for _, word := range words {
-		if !stopWords[word] {
-			wordCount[word]++
-		}
+	result, err := h.translationProvider.Translate(c.Request.Context(), analysis.Text, analysis.Language, target)
+	if err != nil {
+		c.JSON(http.StatusBadGateway, gin.H{"error": "Failed to translate analysis"})
+		return
 	}
 
-	type wordFreq struct {
-		word string
-		freq int
+	senses := make([]models.Sense, len(result.Senses))
+	for i, s := range result.Senses {
+		senses[i] = models.Sense{PartOfSpeech: s.PartOfSpeech, Phonetic: s.Phonetic, Definition: s.Definition, Examples: s.Examples}
+	}
+	tr = models.Translation{
+		AnalysisID:     analysis.ID,
+		SourceLang:     analysis.Language,
+		TargetLang:     target,
+		TranslatedText: result.TranslatedText,
+		Senses:         senses,
 	}
+	if err := h.db.Create(&tr).Error; err != nil {
+		log.Printf("Warning: failed to save translation for analysis %d: %v", analysis.ID, err)
+	}
+
+	c.JSON(http.StatusOK, tr)
+}
 
-	var sortedWords []wordFreq
-	for word, freq := range wordCount {
-		sortedWords = append(sortedWords, wordFreq{word, freq})
+// performTextAnalysis performs the actual text analysis, via h.nlpProvider
+// for sentiment/entities/readability and h.extractKeywords for keywords
+// (which may also go through h.nlpProvider, or TextHandler's own TF-IDF
+// path — see extractKeywords).
+func (h *TextHandler) performTextAnalysis(ctx context.Context, text, language, keywordMode string) models.TextAnalysis {
+	if language == "" {
+		language = "en"
 	}
 
-	// Simple bubble sort for top 10
-	for i := 0; i < len(sortedWords)-1; i++ {
-		for j := 0; j < len(sortedWords)-i-1; j++ {
-			if sortedWords[j].freq < sortedWords[j+1].freq {
-				sortedWords[j], sortedWords[j+1] = sortedWords[j+1], sortedWords[j]
-			}
-		}
+	analysis := models.TextAnalysis{
+		Text:     text,
+		Language: language,
 	}
 
-	var keywords []models.KeywordResponse
-	limit := 10
-	if len(sortedWords) < limit {
-		limit = len(sortedWords)
+	sentiment, err := h.nlpProvider.Sentiment(ctx, text, language)
+	if err != nil {
+		log.Printf("Warning: nlp sentiment analysis failed, falling back to neutral: %v", err)
+		sentiment = nlp.SentimentResult{Label: "neutral", Confidence: 0.5}
+	}
+	analysis.Sentiment = models.SentimentAnalysis{
+		Score:      sentiment.Score,
+		Label:      sentiment.Label,
+		Confidence: sentiment.Confidence,
 	}
 
-	for i := 0; i < limit; i++ {
-		keywords = append(keywords, models.KeywordResponse{
-			Word:      sortedWords[i].word,
-			Frequency: sortedWords[i].freq,
-			Relevance: float64(sortedWords[i].freq) / float64(totalWords),
+	// Extract keywords
+	keywords := h.extractKeywords(ctx, text, language, keywordMode)
+	for _, keyword := range keywords {
+		analysis.Keywords = append(analysis.Keywords, models.Keyword{
+			Word:      keyword.Word,
+			Frequency: keyword.Frequency,
+			Relevance: keyword.Relevance,
 		})
 	}
 
-	return keywords
-}
-
-// extractEntities extracts named entities from text
-func (h *TextHandler) extractEntities(text string) []models.EntityResponse {
-	var entities []models.EntityResponse
-
-	// Simple patterns for entity detection
-	patterns := map[string]*regexp.Regexp{
-		"person":       regexp.MustCompile(`\b[A-Z][a-z]+ [A-Z][a-z]+\b`),
-		"organization": regexp.MustCompile(`\b[A-Z][a-zA-Z]+ (Inc|LLC|Corp|Company|Corporation|Ltd)\b`),
-		"location":     regexp.MustCompile(`\b(New York|Los Angeles|Chicago|Houston|Phoenix|Philadelphia|San Antonio|San Diego|Dallas|San Jose|Austin|Jacksonville|Fort Worth|Columbus|Charlotte|San Francisco|Indianapolis|Seattle|Denver|Washington|Boston|El Paso|Detroit|Nashville|Portland|Memphis|Oklahoma City|Las Vegas|Louisville|Baltimore|Milwaukee|Albuquerque|Tucson|Fresno|Sacramento|Mesa|Kansas City|Atlanta|Long Beach|Colorado Springs|Raleigh|Miami|Virginia Beach|Omaha|Oakland|Minneapolis|Tulsa|Arlington|Tampa|New Orleans)\b`),
+	entities, err := h.nlpProvider.Entities(ctx, text, language)
+	if err != nil {
+		log.Printf("Warning: nlp entity extraction failed: %v", err)
+	}
+	for _, entity := range entities {
+		analysis.Entities = append(analysis.Entities, models.Entity{
+			Text:       entity.Text,
+			Type:       entity.Type,
+			Confidence: entity.Confidence,
+			StartPos:   entity.StartPos,
+			EndPos:     entity.EndPos,
+		})
 	}
 
-	for entityType, pattern := range patterns {
-		matches := pattern.FindAllString(text, -1)
-		for _, match := range matches {
-			entities = append(entities, models.EntityResponse{
-				Text:       match,
-				Type:       entityType,
-				Confidence: 0.8,
-			})
-		}
+	readability, err := h.nlpProvider.Readability(ctx, text, language)
+	if err != nil {
+		log.Printf("Warning: nlp readability scoring failed: %v", err)
 	}
+	analysis.Readability = models.ReadabilityAnalysis{Score: readability.Score, Level: readability.Level}
 
-	return entities
+	return analysis
 }
 
-// calculateReadability calculates readability score
-func (h *TextHandler) calculateReadability(text string) models.ReadabilityAnalysis {
-	sentences := regexp.MustCompile(`[.!?]+`).Split(text, -1)
-	sentenceCount := 0
-	for _, s := range sentences {
-		if strings.TrimSpace(s) != "" {
-			sentenceCount++
-		}
+// extractKeywords extracts keywords via h.nlpProvider, or TF-IDF (mode ==
+// "tfidf") to weight words by their rarity across the whole corpus rather
+// than just this document. TF-IDF stays handler-local rather than going
+// through Provider since it depends on the KeywordDocFreq table in
+// Postgres, not something a remote HTTPProvider/GRPCProvider could own.
+func (h *TextHandler) extractKeywords(ctx context.Context, text, language, mode string) []models.KeywordResponse {
+	if mode == "tfidf" {
+		return h.extractKeywordsTFIDF(text)
 	}
 
-	words := regexp.MustCompile(`\b\w+\b`).FindAllString(text, -1)
-	wordCount := len(words)
+	keywords, err := h.nlpProvider.Keywords(ctx, text, language, mode)
+	if err != nil {
+		log.Printf("Warning: nlp keyword extraction failed: %v", err)
+		return nil
+	}
+	responses := make([]models.KeywordResponse, len(keywords))
+	for i, k := range keywords {
+		responses[i] = models.KeywordResponse{Word: k.Word, Frequency: k.Frequency, Relevance: k.Relevance}
+	}
+	return responses
+}
 
-	syllables := 0
-	for _, word := range words {
-		syllables += h.countSyllables(word)
+// extractKeywordsTFIDF scores this document's words by TF-IDF against the
+// persisted KeywordDocFreq corpus table, incrementing each word's document
+// count as it goes so later documents' IDF accounts for this one too.
+func (h *TextHandler) extractKeywordsTFIDF(text string) []models.KeywordResponse {
+	termFreq := extract.WordFrequencies(text, nil)
+	if len(termFreq) == 0 {
+		return nil
 	}
 
-	if sentenceCount == 0 || wordCount == 0 {
-		return models.ReadabilityAnalysis{
-			Score: 0,
-			Level: "Unreadable",
-		}
+	docFreq := make(map[string]int, len(termFreq))
+	for word := range termFreq {
+		docFreq[word] = h.incrementDocFreq(word)
 	}
 
-	avgSentenceLength := float64(wordCount) / float64(sentenceCount)
-	avgSyllablesPerWord := float64(syllables) / float64(wordCount)
+	var totalDocs int64
+	h.db.Model(&models.TextAnalysis{}).Count(&totalDocs)
+	totalDocs++ // this document hasn't been saved yet
 
-	// Simplified Flesch Reading Ease formula
-	score := 206.835 - (1.015 * avgSentenceLength) - (84.6 * avgSyllablesPerWord)
+	scores := extract.TFIDF(termFreq, docFreq, int(totalDocs))
 
-	level := "Very Difficult"
-	if score >= 90 {
-		level = "Very Easy"
-	} else if score >= 80 {
-		level = "Easy"
-	} else if score >= 70 {
-		level = "Fairly Easy"
-	} else if score >= 60 {
-		level = "Standard"
-	} else if score >= 50 {
-		level = "Fairly Difficult"
-	} else if score >= 30 {
-		level = "Difficult"
+	type scoredWord struct {
+		word  string
+		score float64
 	}
-
-	finalScore := math.Max(0, math.Min(100, score))
-	return models.ReadabilityAnalysis{
-		Score: math.Round(finalScore*10) / 10,
-		Level: level,
+	ranked := make([]scoredWord, 0, len(scores))
+	for word, score := range scores {
+		ranked = append(ranked, scoredWord{word, score})
 	}
-}
+	sort.Slice(ranked, func(i, j int) bool {
+		if ranked[i].score != ranked[j].score {
+			return ranked[i].score > ranked[j].score
+		}
+		return ranked[i].word < ranked[j].word // deterministic tie-break
+	})
 
-// countSyllables counts syllables in a word (simplified)
-func (h *TextHandler) countSyllables(word string) int {
-	word = strings.ToLower(word)
-	if len(word) <= 3 {
-		return 1
+	limit := 10
+	if len(ranked) < limit {
+		limit = len(ranked)
 	}
 
-	vowels := "aeiouy"
-	count := 0
-	previousWasVowel := false
-
-	for _, char := range word {
-		isVowel := strings.ContainsRune(vowels, char)
-		if isVowel && !previousWasVowel {
-			count++
+	responses := make([]models.KeywordResponse, limit)
+	for i := 0; i < limit; i++ {
+		responses[i] = models.KeywordResponse{
+			Word:      ranked[i].word,
+			Frequency: termFreq[ranked[i].word],
+			Relevance: ranked[i].score,
 		}
-		previousWasVowel = isVowel
-	}
-
-	// Adjust for silent 'e'
-	if strings.HasSuffix(word, "e") {
-		count--
 	}
+	return responses
+}
 
-	if count < 1 {
-		count = 1
+// incrementDocFreq upserts word's corpus-wide document count and returns
+// the updated value.
+func (h *TextHandler) incrementDocFreq(word string) int {
+	var docFreq models.KeywordDocFreq
+	if err := h.db.Where("word = ?", word).First(&docFreq).Error; err != nil {
+		docFreq = models.KeywordDocFreq{Word: word, DocFrequency: 1}
+		h.db.Create(&docFreq)
+		return 1
 	}
-
-	return count
+	docFreq.DocFrequency++
+	h.db.Save(&docFreq)
+	return docFreq.DocFrequency
 }
@@ -0,0 +1,41 @@
+package middleware
+
+import (
+	cryptorand "crypto/rand"
+	"encoding/hex"
+
+	"github.com/bartosz/stocks-out-for-harambe/backend/internal/audit"
+	"github.com/gin-gonic/gin"
+)
+
+// Audit attaches an audit.Actor to the request's context, derived from the
+// same caller identity ratelimit.go's callerKey uses, plus a fresh request
+// ID, so every audit.Record call made while handling this request is
+// attributed without threading the actor through handler signatures.
+func Audit() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		key, _ := callerKey(c)
+
+		actor := audit.Actor{
+			Actor:     key,
+			RequestID: newRequestID(),
+			IP:        c.ClientIP(),
+			UserAgent: c.Request.UserAgent(),
+		}
+
+		ctx := audit.WithActor(c.Request.Context(), actor)
+		c.Request = c.Request.WithContext(ctx)
+		c.Next()
+	}
+}
+
+// newRequestID draws a random 16-byte hex-encoded ID from crypto/rand. On
+// the extremely unlikely read failure, it falls back to an all-zero ID
+// rather than blocking the request.
+func newRequestID() string {
+	var buf [16]byte
+	if _, err := cryptorand.Read(buf[:]); err != nil {
+		return hex.EncodeToString(buf[:])
+	}
+	return hex.EncodeToString(buf[:])
+}
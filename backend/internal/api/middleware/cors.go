@@ -1,32 +1,65 @@
 package middleware
 
 import (
+	"sync"
+	"time"
+
 	"github.com/bartosz/stocks-out-for-harambe/backend/internal/config"
 	"github.com/gin-contrib/cors"
 	"github.com/gin-gonic/gin"
-	"time"
 )
 
-// CORS returns a configured CORS middleware
+// CORS returns a configured CORS middleware. cfg.AllowedOrigins entries
+// may use gin-contrib/cors's own "https://*.example.com"-style wildcard
+// segments; for full regex matching, build cfg via a CORSByRoute policy
+// with AllowOriginFunc set instead.
 func CORS(cfg config.CORSConfig) gin.HandlerFunc {
-	return cors.New(cors.Config{
+	return cors.New(corsConfigFor(cfg))
+}
+
+func corsConfigFor(cfg config.CORSConfig) cors.Config {
+	return cors.Config{
 		AllowOrigins:     cfg.AllowedOrigins,
 		AllowMethods:     cfg.AllowedMethods,
 		AllowHeaders:     cfg.AllowedHeaders,
 		ExposeHeaders:    []string{"Content-Length", "Content-Type"},
 		AllowCredentials: true,
 		MaxAge:           12 * time.Hour,
-	})
+		AllowWildcard:    true,
+	}
+}
+
+// DynamicCORS wraps a CORS handler behind a swappable pointer, so
+// WatchCORSConfig's onChange callback can roll in a freshly reloaded
+// allow-list without replacing the gin.HandlerFunc already registered via
+// router.Use.
+type DynamicCORS struct {
+	mu      sync.RWMutex
+	handler gin.HandlerFunc
+}
+
+// NewDynamicCORS builds a DynamicCORS starting from cfg.
+func NewDynamicCORS(cfg config.CORSConfig) *DynamicCORS {
+	d := &DynamicCORS{}
+	d.Set(cfg)
+	return d
 }
 
-// SecurityHeaders adds security headers to responses
-func SecurityHeaders() gin.HandlerFunc {
-	return gin.HandlerFunc(func(c *gin.Context) {
-		c.Header("X-Content-Type-Options", "nosniff")
-		c.Header("X-Frame-Options", "DENY")
-		c.Header("X-XSS-Protection", "1; mode=block")
-		c.Header("Referrer-Policy", "strict-origin-when-cross-origin")
-		c.Header("Content-Security-Policy", "default-src 'self'")
-		c.Next()
-	})
-}
\ No newline at end of file
+// Set replaces the active CORS policy. Safe to call concurrently with
+// Handler's returned middleware serving requests.
+func (d *DynamicCORS) Set(cfg config.CORSConfig) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.handler = CORS(cfg)
+}
+
+// Handler returns the gin.HandlerFunc to register once with router.Use;
+// it always delegates to whichever policy Set last installed.
+func (d *DynamicCORS) Handler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		d.mu.RLock()
+		h := d.handler
+		d.mu.RUnlock()
+		h(c)
+	}
+}
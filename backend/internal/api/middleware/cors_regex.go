@@ -0,0 +1,32 @@
+package middleware
+
+import (
+	"fmt"
+	"regexp"
+)
+
+// RegexOriginMatcher compiles patterns as regular expressions (each
+// implicitly anchored with ^...$) and returns a func suitable for
+// cors.Config.AllowOriginFunc, matching any origin against any pattern.
+// Use this over plain wildcard AllowOrigins entries when a single
+// pattern needs to catch an open-ended set of hostnames, e.g.
+// `https://pr-\d+\.preview\.example\.com` for preview-deploy origins.
+func RegexOriginMatcher(patterns []string) (func(origin string) bool, error) {
+	compiled := make([]*regexp.Regexp, 0, len(patterns))
+	for _, p := range patterns {
+		re, err := regexp.Compile("^" + p + "$")
+		if err != nil {
+			return nil, fmt.Errorf("invalid CORS origin pattern %q: %w", p, err)
+		}
+		compiled = append(compiled, re)
+	}
+
+	return func(origin string) bool {
+		for _, re := range compiled {
+			if re.MatchString(origin) {
+				return true
+			}
+		}
+		return false
+	}, nil
+}
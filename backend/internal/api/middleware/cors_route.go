@@ -0,0 +1,56 @@
+package middleware
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/gin-contrib/cors"
+	"github.com/gin-gonic/gin"
+)
+
+// CORSByRoute applies a different cors.Config per route, keyed by a
+// route-prefix pattern ending in "/*" (matching anything under it) or an
+// exact path. It's matched against c.FullPath() (gin's route template,
+// e.g. "/api/v1/admin/:id"), so /api/v1/admin/* can lock CORS down to one
+// origin while /api/v1/public/* stays wide open, all from one globally
+// registered middleware. Each policy's AllowOrigins may use
+// gin-contrib/cors's wildcard syntax ("https://*.example.com") directly;
+// for full regex matching (e.g. to catch preview-deploy hostnames like
+// "https://pr-123.preview.example.com" without listing each one), set
+// that policy's AllowOriginFunc to RegexOriginMatcher's result.
+func CORSByRoute(policies map[string]cors.Config) gin.HandlerFunc {
+	handlers := make(map[string]gin.HandlerFunc, len(policies))
+	patterns := make([]string, 0, len(policies))
+	for pattern, cfg := range policies {
+		handlers[pattern] = cors.New(cfg)
+		patterns = append(patterns, pattern)
+	}
+	// Longest pattern first, so a more specific "/api/v1/admin/*" wins
+	// over a catch-all "/api/v1/*" covering the same request.
+	sort.Slice(patterns, func(i, j int) bool { return len(patterns[i]) > len(patterns[j]) })
+
+	return func(c *gin.Context) {
+		path := c.FullPath()
+		if path == "" {
+			path = c.Request.URL.Path
+		}
+		for _, pattern := range patterns {
+			if routePatternMatches(pattern, path) {
+				handlers[pattern](c)
+				return
+			}
+		}
+		c.Next()
+	}
+}
+
+// routePatternMatches reports whether path falls under pattern. A
+// pattern ending in "/*" matches itself (sans the suffix) and anything
+// nested below it; any other pattern must match exactly.
+func routePatternMatches(pattern, path string) bool {
+	if strings.HasSuffix(pattern, "/*") {
+		prefix := strings.TrimSuffix(pattern, "/*")
+		return path == prefix || strings.HasPrefix(path, prefix+"/")
+	}
+	return pattern == path
+}
@@ -0,0 +1,91 @@
+package middleware
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/bartosz/stocks-out-for-harambe/backend/internal/config"
+	"github.com/fsnotify/fsnotify"
+)
+
+// WatchCORSConfig watches the allow-list file at path and calls onChange
+// with a freshly parsed config.CORSConfig every time it's written, so
+// operators can whitelist a new frontend domain without restarting the
+// backend. The file holds one origin pattern per line (blank lines and
+// "#"-prefixed comments are skipped) — the same AllowedOrigins entries
+// CORSConfig would otherwise take from the comma-separated
+// CORS_ALLOWED_ORIGINS env var, just newline-separated for easier
+// editing. AllowedMethods/AllowedHeaders are left at CORS's defaults;
+// this only reloads the origin list. Callers should arrange to Close the
+// returned Watcher on shutdown.
+func WatchCORSConfig(path string, onChange func(config.CORSConfig)) (*fsnotify.Watcher, error) {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, fmt.Errorf("failed to start CORS config watcher: %w", err)
+	}
+
+	// Watch the containing directory rather than the file itself: editors
+	// commonly replace a file via rename-into-place, which most platforms
+	// report against the directory, not a standing watch on the old inode.
+	if err := watcher.Add(filepath.Dir(path)); err != nil {
+		watcher.Close()
+		return nil, fmt.Errorf("failed to watch %s: %w", path, err)
+	}
+
+	go func() {
+		for {
+			select {
+			case event, ok := <-watcher.Events:
+				if !ok {
+					return
+				}
+				if filepath.Clean(event.Name) != filepath.Clean(path) {
+					continue
+				}
+				if event.Op&(fsnotify.Write|fsnotify.Create) == 0 {
+					continue
+				}
+				cfg, err := loadCORSConfigFile(path)
+				if err != nil {
+					log.Printf("Warning: failed to reload CORS config from %s: %v", path, err)
+					continue
+				}
+				onChange(cfg)
+			case err, ok := <-watcher.Errors:
+				if !ok {
+					return
+				}
+				log.Printf("Warning: CORS config watcher error: %v", err)
+			}
+		}
+	}()
+
+	return watcher, nil
+}
+
+// loadCORSConfigFile parses path into a CORSConfig, keeping
+// AllowedMethods/AllowedHeaders at the same defaults config.New uses.
+func loadCORSConfigFile(path string) (config.CORSConfig, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return config.CORSConfig{}, err
+	}
+
+	var origins []string
+	for _, line := range strings.Split(string(data), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		origins = append(origins, line)
+	}
+
+	return config.CORSConfig{
+		AllowedOrigins: origins,
+		AllowedMethods: []string{"GET", "POST", "PUT", "DELETE", "OPTIONS"},
+		AllowedHeaders: []string{"Origin", "Content-Type", "Accept", "Authorization", "X-Requested-With"},
+	}, nil
+}
@@ -0,0 +1,124 @@
+package middleware
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/bartosz/stocks-out-for-harambe/backend/internal/config"
+	"github.com/gin-gonic/gin"
+	"golang.org/x/time/rate"
+)
+
+// Tier identifies how expensive a route is, which buckets it into a
+// separate set of per-caller limiters.
+type Tier string
+
+const (
+	// TierCheap covers simple list/get reads.
+	TierCheap Tier = "cheap"
+	// TierExpensive covers crawling, sample generation, and the query
+	// endpoint, which do real work or call out to the firecrawl service.
+	TierExpensive Tier = "expensive"
+)
+
+// limiterTTL is how long an idle (ip, tier) limiter is kept before the
+// janitor reclaims it. Without this, every distinct IP that ever made a
+// request would live in memory for the life of the process.
+const limiterTTL = 10 * time.Minute
+
+type limiterEntry struct {
+	limiter    *rate.Limiter
+	lastSeenAt time.Time
+}
+
+// RateLimiter is a token-bucket limiter keyed by (caller, tier), with
+// separate rates per tier and a higher bucket for authenticated callers.
+type RateLimiter struct {
+	cfg config.RateLimitConfig
+
+	mu       sync.Mutex
+	limiters map[string]*limiterEntry
+}
+
+// NewRateLimiter creates a RateLimiter and starts its background janitor.
+func NewRateLimiter(cfg config.RateLimitConfig) *RateLimiter {
+	rl := &RateLimiter{
+		cfg:      cfg,
+		limiters: make(map[string]*limiterEntry),
+	}
+	go rl.janitor()
+	return rl
+}
+
+func (rl *RateLimiter) janitor() {
+	ticker := time.NewTicker(limiterTTL)
+	defer ticker.Stop()
+	for range ticker.C {
+		cutoff := time.Now().Add(-limiterTTL)
+		rl.mu.Lock()
+		for key, entry := range rl.limiters {
+			if entry.lastSeenAt.Before(cutoff) {
+				delete(rl.limiters, key)
+			}
+		}
+		rl.mu.Unlock()
+	}
+}
+
+func (rl *RateLimiter) limiterFor(key string, tier Tier, authenticated bool) *rate.Limiter {
+	rps, burst := rl.cfg.CheapRPS, rl.cfg.CheapBurst
+	if tier == TierExpensive {
+		rps, burst = rl.cfg.ExpensiveRPS, rl.cfg.ExpensiveBurst
+	}
+	if authenticated && rl.cfg.AuthenticatedMultiplier > 0 {
+		rps *= rl.cfg.AuthenticatedMultiplier
+		burst = int(float64(burst) * rl.cfg.AuthenticatedMultiplier)
+	}
+
+	cacheKey := fmt.Sprintf("%s:%s", tier, key)
+
+	rl.mu.Lock()
+	defer rl.mu.Unlock()
+
+	entry, ok := rl.limiters[cacheKey]
+	if !ok {
+		entry = &limiterEntry{limiter: rate.NewLimiter(rate.Limit(rps), burst)}
+		rl.limiters[cacheKey] = entry
+	}
+	entry.lastSeenAt = time.Now()
+	return entry.limiter
+}
+
+// Limit returns a Gin middleware enforcing tier's bucket for the calling
+// IP, or a higher authenticated bucket keyed by bearer token when an
+// Authorization header is present.
+func (rl *RateLimiter) Limit(tier Tier) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		key, authenticated := callerKey(c)
+		limiter := rl.limiterFor(key, tier, authenticated)
+
+		if !limiter.Allow() {
+			c.Header("Retry-After", "1")
+			c.AbortWithStatusJSON(http.StatusTooManyRequests, gin.H{
+				"error": "rate limit exceeded, please slow down",
+			})
+			return
+		}
+
+		c.Next()
+	}
+}
+
+// callerKey identifies the caller for bucketing: the bearer token when
+// present (so one authenticated user keeps one bucket across IPs), falling
+// back to remote IP for anonymous callers.
+func callerKey(c *gin.Context) (key string, authenticated bool) {
+	if auth := c.GetHeader("Authorization"); auth != "" {
+		token := strings.TrimPrefix(auth, "Bearer ")
+		return token, true
+	}
+	return c.ClientIP(), false
+}
@@ -1,25 +1,72 @@
 package api
 
 import (
+	"log"
 	"net/http"
+	"time"
 
 	"github.com/bartosz/stocks-out-for-harambe/backend/internal/api/handlers"
 	"github.com/bartosz/stocks-out-for-harambe/backend/internal/api/middleware"
+	"github.com/bartosz/stocks-out-for-harambe/backend/internal/audit"
 	"github.com/bartosz/stocks-out-for-harambe/backend/internal/config"
+	"github.com/bartosz/stocks-out-for-harambe/backend/internal/filtering"
+	"github.com/bartosz/stocks-out-for-harambe/backend/internal/jobs"
+	"github.com/bartosz/stocks-out-for-harambe/backend/internal/nlp"
+	"github.com/bartosz/stocks-out-for-harambe/backend/internal/observability"
+	"github.com/bartosz/stocks-out-for-harambe/backend/internal/progress"
+	"github.com/bartosz/stocks-out-for-harambe/backend/internal/search"
 	"github.com/bartosz/stocks-out-for-harambe/backend/internal/storage"
+	"github.com/bartosz/stocks-out-for-harambe/backend/internal/translation"
 	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
 )
 
+// staleSessionThreshold is how long an "in_progress" session can go without
+// a DB update before it's assumed to belong to a crashed process and gets
+// requeued on startup.
+const staleSessionThreshold = 10 * time.Minute
+
 // NewRouter creates and configures the API router
 func NewRouter(cfg *config.Config, db *storage.Database) *gin.Engine {
 	// Create Gin router
 	router := gin.New()
 
+	// Install the audit Recorder used by every audit.Record/RecordFromHook
+	// call, including the GORM model hooks on Dataset and TextAnalysis.
+	audit.SetDefault(audit.BuildRecorder(cfg.Auditing, db))
+
+	// GORM query spans/slow-query counter and periodic connection-pool
+	// gauges, both fed into the same /metrics Prometheus registry and (for
+	// spans) whichever tracer InitTracer installed.
+	if err := db.Use(observability.NewGormPlugin(cfg.Observability.DBSlowThreshold)); err != nil {
+		log.Printf("Warning: failed to install GORM tracing plugin: %v", err)
+	}
+	if sqlDB, err := db.DB.DB(); err != nil {
+		log.Printf("Warning: failed to start DB pool stats watcher: %v", err)
+	} else {
+		observability.WatchPoolStats(sqlDB, cfg.Observability.DBPoolStatsInterval)
+	}
+
 	// Add middleware
 	router.Use(gin.Logger())
 	router.Use(gin.Recovery())
-	router.Use(middleware.CORS(cfg.CORS))
-	router.Use(middleware.SecurityHeaders())
+	router.Use(observability.GinMiddleware(cfg.Observability.ServiceName))
+	router.Use(corsMiddleware(cfg.CORS))
+	router.Use(middleware.SecurityHeaders(middleware.SecurityOptionsFromConfig(cfg.Security)))
+	router.Use(middleware.Audit())
+
+	rateLimiter := middleware.NewRateLimiter(cfg.RateLimit)
+	cheapLimit := rateLimiter.Limit(middleware.TierCheap)
+	expensiveLimit := rateLimiter.Limit(middleware.TierExpensive)
+
+	// Prometheus metrics endpoint
+	router.GET("/metrics", gin.WrapH(promhttp.Handler()))
+
+	// CSP violation report endpoint, only mounted if SecurityOptions was
+	// configured to point report-uri at it.
+	if cfg.Security.CSPReportURI != "" {
+		router.POST(cfg.Security.CSPReportURI, middleware.CSPReportHandler())
+	}
 
 	// Health check endpoint
 	router.GET("/health", func(c *gin.Context) {
@@ -30,10 +77,52 @@ func NewRouter(cfg *config.Config, db *storage.Database) *gin.Engine {
 		})
 	})
 
+	// Job manager backs the cancellable batch-analyze and firecrawl-ingest
+	// jobs submitted by the text and sitemap handlers below.
+	jobManager := jobs.NewManager(cfg.Firecrawl.ConcurrentJobs)
+
+	// Search index backs full-text search and keyword/entity aggregations on
+	// TextHandler, SQL-backed by default or Elasticsearch when configured.
+	searchIndex := search.BuildIndex(cfg.Search, db)
+
+	// NLP provider backs TextHandler's sentiment/keyword/entity/readability
+	// analysis, builtin lexicon by default or a remote HTTP/gRPC model
+	// server when configured.
+	nlpProvider := nlp.BuildProvider(cfg.NLP)
+
+	// Translation provider backs TextHandler's translation/dictionary
+	// enrichment for non-English analyses; nil (the default) disables it.
+	translationProvider := translation.BuildProvider(cfg.Translation)
+
+	// Progress broker backs JobsHandler.StreamJobEvents, fed by the
+	// per-text progress events BatchAnalyzeText's workers publish.
+	progressBroker := progress.NewBroker()
+
+	// Filter engine backs TextHandler and SitemapHandler's phrase
+	// filtering: an Aho-Corasick matcher over every active Filter's phrase,
+	// rebuilt whenever FilterHandler mutates the filter set. TextHandler
+	// and SitemapHandler call Match directly against the cached matcher
+	// and never trigger a refresh themselves, so StartAutoRefresh also
+	// sweeps expirations on a timer independent of FilterHandler traffic.
+	filterEngine := filtering.NewEngine()
+	if err := filterEngine.Refresh(db); err != nil {
+		log.Printf("Warning: failed to load filters on startup: %v", err)
+	}
+	filterEngine.StartAutoRefresh(db)
+
 	// Initialize handlers
 	chartHandler := handlers.NewChartHandler(db)
-	textHandler := handlers.NewTextHandler(db)
-	sitemapHandler := handlers.NewSitemapHandler(db, cfg)
+	textHandler := handlers.NewTextHandler(db, jobManager, searchIndex, nlpProvider, cfg.NLP.ConcurrentJobs, translationProvider, cfg.Translation.TargetLanguage, progressBroker, filterEngine)
+	sitemapHandler := handlers.NewSitemapHandler(db, cfg, jobManager, filterEngine)
+	auditHandler := handlers.NewAuditHandler(db)
+	jobsHandler := handlers.NewJobsHandler(jobManager, progressBroker)
+	filterHandler := handlers.NewFilterHandler(db, filterEngine)
+
+	// Requeue any session left "in_progress" by a process that died
+	// mid-extraction, so restarting the server doesn't strand in-flight URLs.
+	if err := handlers.RecoverStaleSessions(db, staleSessionThreshold); err != nil {
+		log.Printf("Warning: failed to recover stale extraction sessions: %v", err)
+	}
 
 	// API v1 routes
 	v1 := router.Group("/api/v1")
@@ -61,14 +150,15 @@ func NewRouter(cfg *config.Config, db *storage.Database) *gin.Engine {
 		// Chart data endpoints
 		charts := v1.Group("/charts")
 		{
-			charts.GET("", chartHandler.ListDatasets)
+			charts.GET("", cheapLimit, chartHandler.ListDatasets)
 			charts.POST("", chartHandler.CreateDataset)
-			charts.GET("/:id", chartHandler.GetDataset)
+			charts.GET("/:id", cheapLimit, chartHandler.GetDataset)
 			charts.PUT("/:id", chartHandler.UpdateDataset)
 			charts.DELETE("/:id", chartHandler.DeleteDataset)
 			charts.POST("/:id/data", chartHandler.AddDataPoints)
-			charts.GET("/:id/data", chartHandler.GetDataPoints)
+			charts.GET("/:id/data", cheapLimit, chartHandler.GetDataPoints)
 			charts.DELETE("/:id/data/:pointId", chartHandler.DeleteDataPoint)
+			charts.POST("/:id/query", expensiveLimit, chartHandler.QueryDataset)
 		}
 
 		// Text analysis endpoints
@@ -81,19 +171,28 @@ func NewRouter(cfg *config.Config, db *storage.Database) *gin.Engine {
 			text.DELETE("/analyses/:id", textHandler.DeleteAnalysis)
 			text.GET("/keywords", textHandler.GetPopularKeywords)
 			text.GET("/entities", textHandler.GetPopularEntities)
+			text.GET("/search", cheapLimit, textHandler.SearchText)
+			text.GET("/sentiment-trend", cheapLimit, textHandler.GetSentimentTrend)
+			text.GET("/analyses/:id/translation", cheapLimit, textHandler.GetTranslation)
 		}
 
 		// Sitemap and extraction endpoints
 		sitemap := v1.Group("/sitemap")
 		{
-			sitemap.POST("/discover", sitemapHandler.DiscoverSitemap)
-			sitemap.POST("/extract/batch", sitemapHandler.StartBatchExtraction)
-			sitemap.GET("/extract/:sessionId/progress", sitemapHandler.GetExtractionProgress)
+			sitemap.POST("/discover", expensiveLimit, sitemapHandler.DiscoverSitemap)
+			sitemap.POST("/extract/batch", expensiveLimit, sitemapHandler.StartBatchExtraction)
+			sitemap.GET("/extract/:sessionId/progress", cheapLimit, sitemapHandler.GetExtractionProgress)
+			sitemap.GET("/extract/:sessionId/stream", sitemapHandler.StreamExtractionProgress)
 			sitemap.POST("/extract/:sessionId/cancel", sitemapHandler.CancelExtraction)
-			sitemap.POST("/extract/:sessionId/retry", sitemapHandler.RetryFailedExtractions)
-			sitemap.GET("/extract/:sessionId", sitemapHandler.GetExtractionDetails)
+			sitemap.POST("/extract/:sessionId/pause", sitemapHandler.PauseExtraction)
+			sitemap.POST("/extract/:sessionId/resume", sitemapHandler.ResumeExtraction)
+			sitemap.POST("/extract/:sessionId/retry", expensiveLimit, sitemapHandler.RetryFailedExtractions)
+			sitemap.GET("/extract/:sessionId/agents", cheapLimit, sitemapHandler.GetExtractionAgents)
+			sitemap.GET("/extract/:sessionId", cheapLimit, sitemapHandler.GetExtractionDetails)
+			sitemap.GET("/extract/:sessionId/export", sitemapHandler.ExportExtractionResults)
 			sitemap.DELETE("/extract/:sessionId", sitemapHandler.DeleteExtractionSession)
-			sitemap.GET("/history", sitemapHandler.GetExtractionHistory)
+			sitemap.GET("/history", cheapLimit, sitemapHandler.GetExtractionHistory)
+			sitemap.GET("/urls/:hash/history", cheapLimit, sitemapHandler.GetURLHistory)
 		}
 
 		// Extraction history proxy endpoints (proxy to Firecrawl service)
@@ -109,8 +208,33 @@ func NewRouter(cfg *config.Config, db *storage.Database) *gin.Engine {
 		// Sample data endpoints for development
 		samples := v1.Group("/samples")
 		{
-			samples.GET("/chart-data/:type", chartHandler.GenerateSampleData)
-			samples.GET("/text-samples", textHandler.GetSampleTexts)
+			samples.GET("/chart-data/:type", expensiveLimit, chartHandler.GenerateSampleData)
+			samples.GET("/text-samples", cheapLimit, textHandler.GetSampleTexts)
+		}
+
+		// Audit trail endpoints
+		auditGroup := v1.Group("/audit")
+		{
+			auditGroup.GET("/events", cheapLimit, auditHandler.ListEvents)
+		}
+
+		// Background job endpoints (batch analyze, firecrawl ingestion)
+		jobsGroup := v1.Group("/jobs")
+		{
+			jobsGroup.GET("", cheapLimit, jobsHandler.ListJobs)
+			jobsGroup.GET("/:id", cheapLimit, jobsHandler.GetJob)
+			jobsGroup.GET("/:id/events", jobsHandler.StreamJobEvents)
+			jobsGroup.DELETE("/:id", jobsHandler.CancelJob)
+		}
+
+		// Filter/rules endpoints
+		filtersGroup := v1.Group("/filters")
+		{
+			filtersGroup.GET("", cheapLimit, filterHandler.ListFilters)
+			filtersGroup.POST("", filterHandler.CreateFilter)
+			filtersGroup.GET("/:id", cheapLimit, filterHandler.GetFilter)
+			filtersGroup.PUT("/:id", filterHandler.UpdateFilter)
+			filtersGroup.DELETE("/:id", filterHandler.DeleteFilter)
 		}
 
 		// Analytics endpoints
@@ -137,3 +261,19 @@ func NewRouter(cfg *config.Config, db *storage.Database) *gin.Engine {
 
 	return router
 }
+
+// corsMiddleware builds the CORS middleware for cfg. If cfg.WatchFile is
+// set, it starts a DynamicCORS that reloads its allow-list from that file
+// via fsnotify on every write; otherwise it's a static middleware.CORS.
+func corsMiddleware(cfg config.CORSConfig) gin.HandlerFunc {
+	if cfg.WatchFile == "" {
+		return middleware.CORS(cfg)
+	}
+
+	dynamic := middleware.NewDynamicCORS(cfg)
+	if _, err := middleware.WatchCORSConfig(cfg.WatchFile, dynamic.Set); err != nil {
+		log.Printf("Warning: failed to watch CORS config file %s, falling back to static policy: %v", cfg.WatchFile, err)
+		return middleware.CORS(cfg)
+	}
+	return dynamic.Handler()
+}
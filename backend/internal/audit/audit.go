@@ -0,0 +1,93 @@
+// Package audit implements an asynchronous audit trail: every mutation to
+// an audited resource (Dataset, TextAnalysis, ...) is recorded as an Event
+// and handed off to a pluggable Sink (Postgres, stdout, or Elasticsearch)
+// over a bounded channel, so a slow sink never blocks the request path.
+package audit
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// Sink persists audit events. Emit is called from the Recorder's single
+// background goroutine, so a slow implementation delays every event
+// queued behind it but never blocks the original request.
+type Sink interface {
+	Emit(ctx context.Context, event Event) error
+}
+
+type contextKey string
+
+const actorContextKey contextKey = "audit_actor"
+
+// Actor identifies who made a request, for attribution on every audit
+// event recorded while handling it.
+type Actor struct {
+	Actor     string
+	RequestID string
+	IP        string
+	UserAgent string
+}
+
+// WithActor attaches actor to ctx. middleware.Audit calls this once per
+// request so every downstream Record call in that request can attribute
+// its event without threading the actor through every function signature.
+func WithActor(ctx context.Context, actor Actor) context.Context {
+	return context.WithValue(ctx, actorContextKey, actor)
+}
+
+// ActorFromContext returns the Actor attached by WithActor, or the zero
+// value if none was attached — notably true for GORM model hooks, which
+// run inside the database driver and never see the request's
+// context.Context (see RecordFromHook).
+func ActorFromContext(ctx context.Context) Actor {
+	actor, _ := ctx.Value(actorContextKey).(Actor)
+	return actor
+}
+
+// Record builds an Event from ctx's Actor and hands it to the
+// installed default Recorder. It's a no-op if no Recorder has been
+// installed via SetDefault, so callers don't need to guard every call
+// site with a nil check.
+func Record(ctx context.Context, verb, resourceType string, resourceID interface{}, before, after interface{}) {
+	rec := defaultRecorder()
+	if rec == nil {
+		return
+	}
+
+	actor := ActorFromContext(ctx)
+	rec.enqueue(Event{
+		Actor:        actor.Actor,
+		Verb:         verb,
+		ResourceType: resourceType,
+		ResourceID:   fmt.Sprint(resourceID),
+		Before:       marshalDiff(before),
+		After:        marshalDiff(after),
+		RequestID:    actor.RequestID,
+		IP:           actor.IP,
+		UserAgent:    actor.UserAgent,
+		Timestamp:    time.Now(),
+	})
+}
+
+// RecordFromHook records an event with no actor attribution, for GORM
+// model hooks (BeforeCreate/AfterUpdate/AfterDelete) that can't reach the
+// request's context.Context.
+func RecordFromHook(verb, resourceType string, resourceID interface{}, before, after interface{}) {
+	Record(context.Background(), verb, resourceType, resourceID, before, after)
+}
+
+// marshalDiff JSON-encodes v for Event's Before/After columns; v may
+// be nil (create has no Before, delete has no After).
+func marshalDiff(v interface{}) string {
+	if v == nil {
+		return ""
+	}
+	b, err := json.Marshal(v)
+	if err != nil {
+		return ""
+	}
+	return string(b)
+}
@@ -0,0 +1,26 @@
+package audit
+
+import "time"
+
+// Event is one recorded mutation to an audited resource (Dataset,
+// TextAnalysis, ...). It's defined in this package rather than models so
+// that models' GORM hooks can call audit.RecordFromHook without an import
+// cycle.
+type Event struct {
+	ID           uint      `json:"id" gorm:"primary_key"`
+	Actor        string    `json:"actor" gorm:"index"`
+	Verb         string    `json:"verb" gorm:"not null;index"` // create, update, delete, add_points, analyze
+	ResourceType string    `json:"resource_type" gorm:"not null;index"`
+	ResourceID   string    `json:"resource_id" gorm:"index"`
+	Before       string    `json:"before,omitempty" gorm:"type:text"` // JSON snapshot before the mutation, empty for create
+	After        string    `json:"after,omitempty" gorm:"type:text"`  // JSON snapshot after the mutation, empty for delete
+	RequestID    string    `json:"request_id"`
+	IP           string    `json:"ip"`
+	UserAgent    string    `json:"user_agent"`
+	Timestamp    time.Time `json:"timestamp" gorm:"index"`
+}
+
+// TableName sets the table name for Event.
+func (Event) TableName() string {
+	return "audit_events"
+}
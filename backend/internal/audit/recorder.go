@@ -0,0 +1,139 @@
+package audit
+
+import (
+	"context"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/bartosz/stocks-out-for-harambe/backend/internal/config"
+	"github.com/bartosz/stocks-out-for-harambe/backend/internal/metrics"
+	"github.com/bartosz/stocks-out-for-harambe/backend/internal/storage"
+)
+
+// Recorder buffers Events in a bounded channel and drains them to a Sink
+// from a single background goroutine, flushing once BatchSize events have
+// accumulated or FlushInterval elapses (whichever comes first); a flush
+// calls Sink.Emit once per event. A full buffer drops new events rather
+// than blocking the caller, counted by metrics.ObserveAuditEventDropped.
+type Recorder struct {
+	sink          Sink
+	batchSize     int
+	flushInterval time.Duration
+
+	events chan Event
+	done   chan struct{}
+}
+
+// NewRecorder creates a Recorder and starts its background drain loop.
+// bufferSize, batchSize, and flushInterval fall back to sane defaults when
+// zero.
+func NewRecorder(sink Sink, bufferSize, batchSize int, flushInterval time.Duration) *Recorder {
+	if bufferSize <= 0 {
+		bufferSize = 1000
+	}
+	if batchSize <= 0 {
+		batchSize = 20
+	}
+	if flushInterval <= 0 {
+		flushInterval = 2 * time.Second
+	}
+
+	r := &Recorder{
+		sink:          sink,
+		batchSize:     batchSize,
+		flushInterval: flushInterval,
+		events:        make(chan Event, bufferSize),
+		done:          make(chan struct{}),
+	}
+	go r.run()
+	return r
+}
+
+func (r *Recorder) run() {
+	defer close(r.done)
+
+	batch := make([]Event, 0, r.batchSize)
+	ticker := time.NewTicker(r.flushInterval)
+	defer ticker.Stop()
+
+	flush := func() {
+		for _, event := range batch {
+			if err := r.sink.Emit(context.Background(), event); err != nil {
+				log.Printf("audit: failed to emit event (verb=%s resource=%s/%s): %v", event.Verb, event.ResourceType, event.ResourceID, err)
+			}
+		}
+		batch = batch[:0]
+	}
+
+	for {
+		select {
+		case event, ok := <-r.events:
+			if !ok {
+				flush()
+				return
+			}
+			batch = append(batch, event)
+			if len(batch) >= r.batchSize {
+				flush()
+			}
+		case <-ticker.C:
+			flush()
+		}
+	}
+}
+
+// enqueue attempts a non-blocking send; a full buffer increments the
+// back-pressure drop counter instead of blocking the caller.
+func (r *Recorder) enqueue(event Event) {
+	select {
+	case r.events <- event:
+	default:
+		metrics.ObserveAuditEventDropped()
+	}
+}
+
+// Close stops accepting new events and waits for the drain loop to flush
+// whatever's already queued.
+func (r *Recorder) Close() {
+	close(r.events)
+	<-r.done
+}
+
+var (
+	defaultMu sync.RWMutex
+	installed *Recorder
+)
+
+// SetDefault installs rec as the Recorder used by Record/RecordFromHook.
+func SetDefault(rec *Recorder) {
+	defaultMu.Lock()
+	defer defaultMu.Unlock()
+	installed = rec
+}
+
+func defaultRecorder() *Recorder {
+	defaultMu.RLock()
+	defer defaultMu.RUnlock()
+	return installed
+}
+
+// BuildRecorder constructs a Recorder from cfg, selecting its Sink by
+// cfg.Sink, or returns nil when auditing is disabled.
+func BuildRecorder(cfg config.AuditingConfig, db *storage.Database) *Recorder {
+	if !cfg.Enabled {
+		return nil
+	}
+
+	var sink Sink
+	switch cfg.Sink {
+	case "stdout":
+		sink = NewStdoutSink()
+	case "elasticsearch":
+		sink = NewElasticsearchSink(cfg.ElasticsearchURL, cfg.Index)
+	default:
+		sink = NewPostgresSink(db)
+	}
+
+	return NewRecorder(sink, cfg.BufferSize, cfg.BatchSize, cfg.FlushInterval)
+}
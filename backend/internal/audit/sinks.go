@@ -0,0 +1,98 @@
+package audit
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/bartosz/stocks-out-for-harambe/backend/internal/storage"
+)
+
+// PostgresSink persists audit events to the audit_events table via GORM.
+type PostgresSink struct {
+	db *storage.Database
+}
+
+// NewPostgresSink creates a PostgresSink backed by db.
+func NewPostgresSink(db *storage.Database) *PostgresSink {
+	return &PostgresSink{db: db}
+}
+
+// Emit inserts event into the audit_events table.
+func (s *PostgresSink) Emit(ctx context.Context, event Event) error {
+	return s.db.WithContext(ctx).Create(&event).Error
+}
+
+// StdoutSink logs each event as a JSON line, for local development when
+// there's no audit_events table to write to.
+type StdoutSink struct {
+	logger *log.Logger
+}
+
+// NewStdoutSink creates a StdoutSink writing through the standard logger.
+func NewStdoutSink() *StdoutSink {
+	return &StdoutSink{logger: log.Default()}
+}
+
+// Emit logs event as a single JSON line.
+func (s *StdoutSink) Emit(ctx context.Context, event Event) error {
+	b, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("failed to marshal audit event: %w", err)
+	}
+	s.logger.Printf("audit: %s", b)
+	return nil
+}
+
+// elasticsearchTimeout bounds each document index request so a hung
+// Elasticsearch cluster can't stall the Recorder's drain loop indefinitely.
+const elasticsearchTimeout = 5 * time.Second
+
+// ElasticsearchSink indexes each event as a document via Elasticsearch's
+// plain HTTP API. It deliberately avoids pulling in a client library, since
+// none is used elsewhere in this codebase.
+type ElasticsearchSink struct {
+	url        string
+	index      string
+	httpClient *http.Client
+}
+
+// NewElasticsearchSink creates an ElasticsearchSink posting documents to
+// url's {index}/_doc endpoint.
+func NewElasticsearchSink(url, index string) *ElasticsearchSink {
+	return &ElasticsearchSink{
+		url:        url,
+		index:      index,
+		httpClient: &http.Client{Timeout: elasticsearchTimeout},
+	}
+}
+
+// Emit POSTs event as a new document to the configured index.
+func (s *ElasticsearchSink) Emit(ctx context.Context, event Event) error {
+	body, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("failed to marshal audit event: %w", err)
+	}
+
+	endpoint := fmt.Sprintf("%s/%s/_doc", s.url, s.index)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to build elasticsearch request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to index audit event: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("elasticsearch returned status %d indexing audit event", resp.StatusCode)
+	}
+	return nil
+}
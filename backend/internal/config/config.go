@@ -9,18 +9,26 @@ import (
 
 // Config holds all application configuration
 type Config struct {
-	Port      string
-	GinMode   string
-	Database  DatabaseConfig
-	CORS      CORSConfig
-	JWT       JWTConfig
-	API       APIConfig
-	Cache     CacheConfig
-	Firecrawl FirecrawlConfig
+	Port          string
+	GinMode       string
+	Database      DatabaseConfig
+	CORS          CORSConfig
+	JWT           JWTConfig
+	API           APIConfig
+	Cache         CacheConfig
+	Firecrawl     FirecrawlConfig
+	RateLimit     RateLimitConfig
+	Auditing      AuditingConfig
+	Search        SearchConfig
+	NLP           NLPConfig
+	Translation   TranslationConfig
+	Security      SecurityConfig
+	Observability ObservabilityConfig
 }
 
 // DatabaseConfig holds database configuration
 type DatabaseConfig struct {
+	Driver   string // "postgres" (default), "mysql", "sqlite"
 	Host     string
 	Port     string
 	User     string
@@ -28,6 +36,15 @@ type DatabaseConfig struct {
 	Name     string
 	SSLMode  string
 	URL      string // Full connection string
+
+	// ReadReplicas, if non-empty, registers a GORM dbresolver that routes
+	// SELECTs to these DSNs (picked at random per query) and writes to the
+	// primary.
+	ReadReplicas []string
+
+	MaxOpenConns    int
+	MaxIdleConns    int
+	ConnMaxLifetime time.Duration
 }
 
 // CORSConfig holds CORS configuration
@@ -35,6 +52,12 @@ type CORSConfig struct {
 	AllowedOrigins []string
 	AllowedMethods []string
 	AllowedHeaders []string
+
+	// WatchFile, if set, is a newline-delimited allow-list file that
+	// middleware.WatchCORSConfig reloads on every write, letting
+	// operators whitelist a new frontend domain without restarting the
+	// backend. See middleware.DynamicCORS for how router.go wires it up.
+	WatchFile string
 }
 
 // JWTConfig holds JWT configuration
@@ -55,6 +78,17 @@ type CacheConfig struct {
 	TTL      time.Duration
 }
 
+// RateLimitConfig holds the token-bucket rates used by the rate-limit
+// middleware, split by tier ("cheap" reads vs "expensive" writes/crawls)
+// and by whether the caller is authenticated.
+type RateLimitConfig struct {
+	CheapRPS                float64
+	CheapBurst              int
+	ExpensiveRPS            float64
+	ExpensiveBurst          int
+	AuthenticatedMultiplier float64
+}
+
 // FirecrawlConfig holds firecrawl service configuration
 type FirecrawlConfig struct {
 	BaseURL             string
@@ -65,23 +99,137 @@ type FirecrawlConfig struct {
 	ConcurrentJobs      int
 }
 
+// AuditingConfig holds the auditing subsystem configuration: whether it's
+// on, which Sink backs it, and the async Recorder's batching knobs.
+// Analogous to FirecrawlConfig for an optional downstream service.
+type AuditingConfig struct {
+	Enabled          bool
+	Sink             string // "postgres" (default), "stdout", "elasticsearch"
+	ElasticsearchURL string
+	Index            string
+	BufferSize       int
+	BatchSize        int
+	FlushInterval    time.Duration
+}
+
+// SearchConfig holds the full-text search/aggregation backend configuration.
+// search.BuildIndex uses SQLIndex (querying the existing Postgres tables
+// directly) unless Enabled and URL are both set, in which case it builds an
+// ElasticIndex instead — analogous to AuditingConfig's Sink selection.
+type SearchConfig struct {
+	Enabled     bool
+	URL         string
+	IndexPrefix string
+}
+
+// NLPConfig selects the nlp.Provider TextHandler analyzes text with, and
+// configures whichever of HTTPProvider/GRPCProvider that selection needs,
+// plus the worker pool size BatchAnalyzeText fans out across — analogous to
+// FirecrawlConfig.ConcurrentJobs.
+type NLPConfig struct {
+	Provider       string // "builtin" (default), "http", "grpc"
+	ConcurrentJobs int
+
+	HTTPBaseURL        string
+	HTTPAPIKey         string
+	HTTPTimeoutSeconds int
+	HTTPMaxRetries     int
+
+	GRPCAddress string
+}
+
+// TranslationConfig selects the translation.Provider TextHandler enriches
+// non-English analyses with, and configures whichever of
+// DeepL/Volcano/Caiyun/LibreTranslate that selection needs. TargetLanguage
+// is the language sentiment/keyword lexicons assume (normally "en");
+// analyses whose own Language differs get translated into it before
+// re-running those lexicons, mirroring NLPConfig's provider selection.
+type TranslationConfig struct {
+	Enabled        bool
+	Provider       string // "libretranslate" (default), "deepl", "volcano", "caiyun"
+	TargetLanguage string
+	TimeoutSeconds int
+	MaxRetries     int
+
+	DeepLBaseURL string
+	DeepLAPIKey  string
+
+	VolcanoBaseURL   string
+	VolcanoAccessKey string
+	VolcanoSecretKey string
+
+	CaiyunBaseURL string
+	CaiyunToken   string
+
+	LibreTranslateBaseURL string
+	LibreTranslateAPIKey  string
+}
+
+// SecurityConfig configures the SecurityHeaders middleware's CSP report
+// mode/URI, HSTS, Permissions-Policy, and Cross-Origin-* headers. The CSP
+// directive map itself isn't env-configurable (see
+// middleware.SecurityOptionsFromConfig); only these scalar toggles are.
+type SecurityConfig struct {
+	CSPReportOnly     bool
+	CSPReportURI      string
+	AllowUnsafeInline bool
+
+	HSTSMaxAge            time.Duration
+	HSTSIncludeSubDomains bool
+	HSTSPreload           bool
+
+	PermissionsPolicy         string
+	CrossOriginOpenerPolicy   string
+	CrossOriginEmbedderPolicy string
+	CrossOriginResourcePolicy string
+}
+
+// ObservabilityConfig configures OpenTelemetry tracing and the Prometheus
+// collectors exposed alongside the existing metrics package: HTTP
+// request/latency, GORM query spans, and periodic DB connection-pool
+// gauges. Tracing stays a no-op unless OTLPEndpoint is set, so leaving it
+// unset is equivalent to today's behavior (no exporter, no overhead).
+type ObservabilityConfig struct {
+	ServiceName string
+
+	// OTLPEndpoint is read from the standard OTEL_EXPORTER_OTLP_ENDPOINT
+	// env var. Empty disables trace export entirely.
+	OTLPEndpoint string
+
+	// DBSlowThreshold mirrors storage.NewDatabase's GORM logger
+	// SlowThreshold; queries at or above it increment
+	// metrics.DBSlowQueriesTotal in addition to GORM's own slow-query log
+	// line.
+	DBSlowThreshold time.Duration
+
+	// DBPoolStatsInterval is how often observability.WatchPoolStats scrapes
+	// sql.DB.Stats() into the DB pool gauges.
+	DBPoolStatsInterval time.Duration
+}
+
 // New creates a new configuration instance
 func New() *Config {
 	return &Config{
 		Port:    getEnv("PORT", "8080"),
 		GinMode: getEnv("GIN_MODE", "debug"),
 		Database: DatabaseConfig{
-			Host:     getEnv("DB_HOST", "localhost"),
-			Port:     getEnv("DB_PORT", "5432"),
-			User:     getEnv("DB_USER", "postgres"),
-			Password: getEnv("DB_PASSWORD", "password"),
-			Name:     getEnv("DB_NAME", "synthora_dev"),
-			SSLMode:  getEnv("DB_SSL_MODE", "disable"),
+			Driver:          getEnv("DB_DRIVER", "postgres"),
+			Host:            getEnv("DB_HOST", "localhost"),
+			Port:            getEnv("DB_PORT", "5432"),
+			User:            getEnv("DB_USER", "postgres"),
+			Password:        getEnv("DB_PASSWORD", "password"),
+			Name:            getEnv("DB_NAME", "synthora_dev"),
+			SSLMode:         getEnv("DB_SSL_MODE", "disable"),
+			ReadReplicas:    getEnvAsSlice("DB_READ_REPLICAS", nil),
+			MaxOpenConns:    getEnvAsInt("DB_MAX_OPEN_CONNS", 100),
+			MaxIdleConns:    getEnvAsInt("DB_MAX_IDLE_CONNS", 10),
+			ConnMaxLifetime: parseDuration(getEnv("DB_CONN_MAX_LIFETIME", "1h")),
 		},
 		CORS: CORSConfig{
 			AllowedOrigins: strings.Split(getEnv("CORS_ALLOWED_ORIGINS", "http://localhost:3000,http://localhost:5173"), ","),
 			AllowedMethods: strings.Split(getEnv("CORS_ALLOWED_METHODS", "GET,POST,PUT,DELETE,OPTIONS"), ","),
 			AllowedHeaders: strings.Split(getEnv("CORS_ALLOWED_HEADERS", "Origin,Content-Type,Accept,Authorization,X-Requested-With"), ","),
+			WatchFile:      getEnv("CORS_WATCH_FILE", ""),
 		},
 		JWT: JWTConfig{
 			Secret: getEnv("JWT_SECRET", "your-super-secret-jwt-key"),
@@ -103,6 +251,70 @@ func New() *Config {
 			ExtractionTimeoutMs: getEnvAsInt("FIRECRAWL_EXTRACTION_TIMEOUT_MS", 60000),
 			ConcurrentJobs:      getEnvAsInt("FIRECRAWL_CONCURRENT_JOBS", 5),
 		},
+		RateLimit: RateLimitConfig{
+			CheapRPS:                getEnvAsFloat("RATE_LIMIT_CHEAP_RPS", 20),
+			CheapBurst:              getEnvAsInt("RATE_LIMIT_CHEAP_BURST", 40),
+			ExpensiveRPS:            getEnvAsFloat("RATE_LIMIT_EXPENSIVE_RPS", 2),
+			ExpensiveBurst:          getEnvAsInt("RATE_LIMIT_EXPENSIVE_BURST", 5),
+			AuthenticatedMultiplier: getEnvAsFloat("RATE_LIMIT_AUTHENTICATED_MULTIPLIER", 5),
+		},
+		Auditing: AuditingConfig{
+			Enabled:          getEnvAsBool("AUDIT_ENABLED", true),
+			Sink:             getEnv("AUDIT_SINK", "postgres"),
+			ElasticsearchURL: getEnv("AUDIT_ELASTICSEARCH_URL", ""),
+			Index:            getEnv("AUDIT_ELASTICSEARCH_INDEX", "symbiot-audit-events"),
+			BufferSize:       getEnvAsInt("AUDIT_BUFFER_SIZE", 1000),
+			BatchSize:        getEnvAsInt("AUDIT_BATCH_SIZE", 20),
+			FlushInterval:    parseDuration(getEnv("AUDIT_FLUSH_INTERVAL", "2s")),
+		},
+		Search: SearchConfig{
+			Enabled:     getEnvAsBool("SEARCH_ENABLED", false),
+			URL:         getEnv("SEARCH_ELASTICSEARCH_URL", ""),
+			IndexPrefix: getEnv("SEARCH_INDEX_PREFIX", "symbiot"),
+		},
+		NLP: NLPConfig{
+			Provider:           getEnv("NLP_PROVIDER", "builtin"),
+			ConcurrentJobs:     getEnvAsInt("NLP_CONCURRENT_JOBS", 5),
+			HTTPBaseURL:        getEnv("NLP_HTTP_BASE_URL", ""),
+			HTTPAPIKey:         getEnv("NLP_HTTP_API_KEY", ""),
+			HTTPTimeoutSeconds: getEnvAsInt("NLP_HTTP_TIMEOUT_SECONDS", 10),
+			HTTPMaxRetries:     getEnvAsInt("NLP_HTTP_MAX_RETRIES", 3),
+			GRPCAddress:        getEnv("NLP_GRPC_ADDRESS", ""),
+		},
+		Translation: TranslationConfig{
+			Enabled:               getEnvAsBool("TRANSLATION_ENABLED", false),
+			Provider:              getEnv("TRANSLATION_PROVIDER", "libretranslate"),
+			TargetLanguage:        getEnv("TRANSLATION_TARGET_LANGUAGE", "en"),
+			TimeoutSeconds:        getEnvAsInt("TRANSLATION_TIMEOUT_SECONDS", 10),
+			MaxRetries:            getEnvAsInt("TRANSLATION_MAX_RETRIES", 3),
+			DeepLBaseURL:          getEnv("TRANSLATION_DEEPL_BASE_URL", "https://api-free.deepl.com"),
+			DeepLAPIKey:           getEnv("TRANSLATION_DEEPL_API_KEY", ""),
+			VolcanoBaseURL:        getEnv("TRANSLATION_VOLCANO_BASE_URL", "https://translate.volcengineapi.com"),
+			VolcanoAccessKey:      getEnv("TRANSLATION_VOLCANO_ACCESS_KEY", ""),
+			VolcanoSecretKey:      getEnv("TRANSLATION_VOLCANO_SECRET_KEY", ""),
+			CaiyunBaseURL:         getEnv("TRANSLATION_CAIYUN_BASE_URL", "https://api.interpreter.caiyunai.com"),
+			CaiyunToken:           getEnv("TRANSLATION_CAIYUN_TOKEN", ""),
+			LibreTranslateBaseURL: getEnv("TRANSLATION_LIBRETRANSLATE_BASE_URL", ""),
+			LibreTranslateAPIKey:  getEnv("TRANSLATION_LIBRETRANSLATE_API_KEY", ""),
+		},
+		Security: SecurityConfig{
+			CSPReportOnly:             getEnvAsBool("SECURITY_CSP_REPORT_ONLY", false),
+			CSPReportURI:              getEnv("SECURITY_CSP_REPORT_URI", ""),
+			AllowUnsafeInline:         getEnvAsBool("SECURITY_ALLOW_UNSAFE_INLINE", false),
+			HSTSMaxAge:                parseDuration(getEnv("SECURITY_HSTS_MAX_AGE", "8760h")),
+			HSTSIncludeSubDomains:     getEnvAsBool("SECURITY_HSTS_INCLUDE_SUBDOMAINS", true),
+			HSTSPreload:               getEnvAsBool("SECURITY_HSTS_PRELOAD", false),
+			PermissionsPolicy:         getEnv("SECURITY_PERMISSIONS_POLICY", "geolocation=(), microphone=(), camera=()"),
+			CrossOriginOpenerPolicy:   getEnv("SECURITY_COOP", "same-origin"),
+			CrossOriginEmbedderPolicy: getEnv("SECURITY_COEP", "require-corp"),
+			CrossOriginResourcePolicy: getEnv("SECURITY_CORP", "same-origin"),
+		},
+		Observability: ObservabilityConfig{
+			ServiceName:         getEnv("OTEL_SERVICE_NAME", "symbiot-backend"),
+			OTLPEndpoint:        getEnv("OTEL_EXPORTER_OTLP_ENDPOINT", ""),
+			DBSlowThreshold:     parseDuration(getEnv("DB_SLOW_QUERY_THRESHOLD", "200ms")),
+			DBPoolStatsInterval: parseDuration(getEnv("DB_POOL_STATS_INTERVAL", "15s")),
+		},
 	}
 }
 
@@ -155,3 +367,34 @@ func getEnvAsInt(key string, fallback int) int {
 	}
 	return fallback
 }
+
+// getEnvAsFloat gets environment variable as a float64 with fallback
+func getEnvAsFloat(key string, fallback float64) float64 {
+	if value := os.Getenv(key); value != "" {
+		if floatVal, err := strconv.ParseFloat(value, 64); err == nil {
+			return floatVal
+		}
+	}
+	return fallback
+}
+
+// getEnvAsBool gets environment variable as a bool with fallback
+func getEnvAsBool(key string, fallback bool) bool {
+	if value := os.Getenv(key); value != "" {
+		if boolVal, err := strconv.ParseBool(value); err == nil {
+			return boolVal
+		}
+	}
+	return fallback
+}
+
+// getEnvAsSlice gets a comma-separated environment variable as a string
+// slice with fallback. Unlike the CORS fields, an unset variable returns
+// fallback as-is rather than splitting a default string, since an empty
+// read-replica list is the common case.
+func getEnvAsSlice(key string, fallback []string) []string {
+	if value := os.Getenv(key); value != "" {
+		return strings.Split(value, ",")
+	}
+	return fallback
+}
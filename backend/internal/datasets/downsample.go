@@ -0,0 +1,262 @@
+// Package datasets implements server-side reduction of large ChartDataPoint
+// series, so the frontend never has to pull tens of thousands of rows to
+// render a few hundred pixels of chart.
+package datasets
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/bartosz/stocks-out-for-harambe/backend/internal/models"
+	"github.com/bartosz/stocks-out-for-harambe/backend/internal/storage"
+)
+
+// Mode selects the bucketing strategy used by Downsample.
+type Mode string
+
+const (
+	// ModeLTTB is the Largest-Triangle-Three-Buckets algorithm: it picks the
+	// one point per bucket that best preserves the series' visual shape.
+	ModeLTTB Mode = "lttb"
+	// ModeMinMax keeps both the min and max Y per bucket, useful for
+	// OHLC-ish views where spikes matter more than shape fidelity.
+	ModeMinMax Mode = "min-max"
+	// ModeMean collapses each bucket to its average X and Y.
+	ModeMean Mode = "mean"
+)
+
+// Options configures Downsample.
+type Options struct {
+	// Mode defaults to ModeLTTB when empty.
+	Mode Mode
+}
+
+// Downsample reduces datasetID's ChartDataPoint series to roughly
+// targetPoints while preserving its visual shape, using the algorithm
+// selected by opts.Mode. It streams rows out of the database with a single
+// Rows() pass rather than Preload-ing the full association and then
+// building a second response slice, since the dataset can be tens of
+// thousands of rows.
+//
+// If the series has targetPoints or fewer points, it's returned unchanged.
+func Downsample(ctx context.Context, db *storage.Database, datasetID uint, targetPoints int, opts Options) ([]models.ChartDataPointResponse, error) {
+	points, err := fetchSorted(ctx, db, datasetID)
+	if err != nil {
+		return nil, err
+	}
+
+	if targetPoints < 3 || len(points) <= targetPoints {
+		return toResponses(points), nil
+	}
+
+	switch opts.Mode {
+	case ModeMinMax:
+		return toResponses(minMaxBucket(points, targetPoints)), nil
+	case ModeMean:
+		return toResponses(meanBucket(points, targetPoints)), nil
+	case ModeLTTB, "":
+		return toResponses(lttb(points, targetPoints)), nil
+	default:
+		return nil, fmt.Errorf("datasets: unknown downsample mode %q", opts.Mode)
+	}
+}
+
+// fetchSorted streams every ChartDataPoint for datasetID ordered by X,
+// without materializing a second slice of response structs.
+func fetchSorted(ctx context.Context, db *storage.Database, datasetID uint) ([]models.ChartDataPoint, error) {
+	rows, err := db.WithContext(ctx).Model(&models.ChartDataPoint{}).
+		Where("dataset_id = ?", datasetID).
+		Order("x asc").
+		Rows()
+	if err != nil {
+		return nil, fmt.Errorf("datasets: failed to stream data points: %w", err)
+	}
+	defer rows.Close()
+
+	var points []models.ChartDataPoint
+	for rows.Next() {
+		var p models.ChartDataPoint
+		if err := db.ScanRows(rows, &p); err != nil {
+			return nil, fmt.Errorf("datasets: failed to scan data point: %w", err)
+		}
+		points = append(points, p)
+	}
+	return points, rows.Err()
+}
+
+// lttb implements the Largest-Triangle-Three-Buckets algorithm: the first
+// and last points are always kept, and the remaining n-2 points are split
+// into targetPoints-2 equal-size buckets. From each bucket, the point that
+// forms the largest triangle with the previously selected point and the
+// average of the next bucket is kept.
+func lttb(points []models.ChartDataPoint, targetPoints int) []models.ChartDataPoint {
+	n := len(points)
+	out := make([]models.ChartDataPoint, 0, targetPoints)
+	out = append(out, points[0])
+
+	// Bucket size for the n-2 interior points, split across targetPoints-2
+	// buckets. Using float division and flooring the boundaries keeps
+	// bucket sizes within one of each other, matching the reference LTTB
+	// implementation.
+	bucketSize := float64(n-2) / float64(targetPoints-2)
+
+	selected := 0 // index into points of the last selected point
+	for i := 0; i < targetPoints-2; i++ {
+		bucketStart := int(float64(i)*bucketSize) + 1
+		bucketEnd := int(float64(i+1)*bucketSize) + 1
+		if bucketEnd > n-1 {
+			bucketEnd = n - 1
+		}
+
+		nextStart := bucketEnd
+		nextEnd := int(float64(i+2)*bucketSize) + 1
+		if nextEnd > n-1 {
+			nextEnd = n - 1
+		}
+		if i == targetPoints-3 {
+			// The last bucket's "next bucket" is the always-kept final point.
+			nextEnd = n
+		}
+		avgX, avgY := average(points[nextStart:nextEnd])
+
+		ax, ay := points[selected].X, points[selected].Y
+
+		bestIdx := bucketStart
+		bestArea := -1.0
+		for j := bucketStart; j < bucketEnd; j++ {
+			area := triangleArea(ax, ay, points[j].X, points[j].Y, avgX, avgY)
+			if area > bestArea {
+				bestArea = area
+				bestIdx = j
+			}
+		}
+
+		out = append(out, points[bestIdx])
+		selected = bestIdx
+	}
+
+	out = append(out, points[n-1])
+	return out
+}
+
+// triangleArea computes the absolute area of the triangle with vertices
+// (ax,ay), (bx,by), (cx,cy).
+func triangleArea(ax, ay, bx, by, cx, cy float64) float64 {
+	return 0.5 * abs((ax-cx)*(by-ay)-(ax-bx)*(cy-ay))
+}
+
+func abs(v float64) float64 {
+	if v < 0 {
+		return -v
+	}
+	return v
+}
+
+// average returns the mean X and Y of pts, or (0, 0) for an empty slice.
+func average(pts []models.ChartDataPoint) (float64, float64) {
+	if len(pts) == 0 {
+		return 0, 0
+	}
+	var sumX, sumY float64
+	for _, p := range pts {
+		sumX += p.X
+		sumY += p.Y
+	}
+	n := float64(len(pts))
+	return sumX / n, sumY / n
+}
+
+// minMaxBucket splits points into targetPoints/2 equal-width buckets (by
+// index) and keeps the min and max Y point from each, sorted back by X.
+func minMaxBucket(points []models.ChartDataPoint, targetPoints int) []models.ChartDataPoint {
+	buckets := targetPoints / 2
+	if buckets < 1 {
+		buckets = 1
+	}
+
+	out := make([]models.ChartDataPoint, 0, buckets*2)
+	for _, bucket := range bucketize(points, buckets) {
+		if len(bucket) == 0 {
+			continue
+		}
+		min, max := bucket[0], bucket[0]
+		for _, p := range bucket {
+			if p.Y < min.Y {
+				min = p
+			}
+			if p.Y > max.Y {
+				max = p
+			}
+		}
+		if min.X == max.X && min.Y == max.Y {
+			out = append(out, min)
+			continue
+		}
+		out = append(out, min, max)
+	}
+
+	sort.Slice(out, func(i, j int) bool { return out[i].X < out[j].X })
+	return out
+}
+
+// meanBucket splits points into targetPoints equal-width buckets (by index)
+// and collapses each to its average X and Y.
+func meanBucket(points []models.ChartDataPoint, targetPoints int) []models.ChartDataPoint {
+	out := make([]models.ChartDataPoint, 0, targetPoints)
+	for _, bucket := range bucketize(points, targetPoints) {
+		if len(bucket) == 0 {
+			continue
+		}
+		avgX, avgY := average(bucket)
+		out = append(out, models.ChartDataPoint{
+			X:         avgX,
+			Y:         avgY,
+			Timestamp: bucket[len(bucket)/2].Timestamp,
+		})
+	}
+	return out
+}
+
+// bucketize splits points into numBuckets equal-size (by count) contiguous
+// slices, the last absorbing any remainder.
+func bucketize(points []models.ChartDataPoint, numBuckets int) [][]models.ChartDataPoint {
+	n := len(points)
+	if numBuckets < 1 {
+		numBuckets = 1
+	}
+	size := float64(n) / float64(numBuckets)
+
+	buckets := make([][]models.ChartDataPoint, 0, numBuckets)
+	for i := 0; i < numBuckets; i++ {
+		start := int(float64(i) * size)
+		end := int(float64(i+1) * size)
+		if i == numBuckets-1 || end > n {
+			end = n
+		}
+		if start >= end {
+			continue
+		}
+		buckets = append(buckets, points[start:end])
+	}
+	return buckets
+}
+
+// toResponses converts ChartDataPoint rows to their response form. IDs and
+// CreatedAt are zero for the averaged points meanBucket synthesizes, since
+// those don't correspond to a single stored row; LTTB and min-max return
+// stored rows unchanged.
+func toResponses(points []models.ChartDataPoint) []models.ChartDataPointResponse {
+	out := make([]models.ChartDataPointResponse, len(points))
+	for i, p := range points {
+		out[i] = models.ChartDataPointResponse{
+			ID:        p.ID,
+			X:         p.X,
+			Y:         p.Y,
+			Label:     p.Label,
+			Timestamp: p.Timestamp,
+			CreatedAt: p.CreatedAt,
+		}
+	}
+	return out
+}
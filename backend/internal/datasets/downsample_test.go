@@ -0,0 +1,110 @@
+package datasets
+
+import (
+	"testing"
+
+	"github.com/bartosz/stocks-out-for-harambe/backend/internal/models"
+)
+
+func makePoints(n int) []models.ChartDataPoint {
+	points := make([]models.ChartDataPoint, n)
+	for i := 0; i < n; i++ {
+		points[i] = models.ChartDataPoint{X: float64(i), Y: float64(i)}
+	}
+	return points
+}
+
+func TestLTTB_KeepsFirstAndLastPoint(t *testing.T) {
+	points := makePoints(100)
+	out := lttb(points, 10)
+
+	if len(out) != 10 {
+		t.Fatalf("expected 10 points, got %d", len(out))
+	}
+	if out[0].X != points[0].X {
+		t.Errorf("expected first point preserved, got X=%v", out[0].X)
+	}
+	if out[len(out)-1].X != points[len(points)-1].X {
+		t.Errorf("expected last point preserved, got X=%v", out[len(out)-1].X)
+	}
+}
+
+func TestLTTB_PreservesXOrder(t *testing.T) {
+	points := makePoints(500)
+	out := lttb(points, 50)
+	for i := 1; i < len(out); i++ {
+		if out[i].X <= out[i-1].X {
+			t.Fatalf("expected strictly increasing X, got %v then %v at index %d", out[i-1].X, out[i].X, i)
+		}
+	}
+}
+
+func TestMinMaxBucket_CapturesExtremes(t *testing.T) {
+	points := []models.ChartDataPoint{
+		{X: 0, Y: 0}, {X: 1, Y: 10}, {X: 2, Y: -5}, {X: 3, Y: 2},
+	}
+	out := minMaxBucket(points, 2)
+
+	var min, max float64 = 1e9, -1e9
+	for _, p := range out {
+		if p.Y < min {
+			min = p.Y
+		}
+		if p.Y > max {
+			max = p.Y
+		}
+	}
+	if min != -5 || max != 10 {
+		t.Errorf("expected bucket min/max to include -5 and 10, got min=%v max=%v", min, max)
+	}
+}
+
+func TestMeanBucket_AveragesEachBucket(t *testing.T) {
+	points := []models.ChartDataPoint{
+		{X: 0, Y: 0}, {X: 1, Y: 2}, {X: 2, Y: 4}, {X: 3, Y: 6},
+	}
+	out := meanBucket(points, 2)
+	if len(out) != 2 {
+		t.Fatalf("expected 2 buckets, got %d", len(out))
+	}
+	if out[0].Y != 1 {
+		t.Errorf("expected first bucket mean 1, got %v", out[0].Y)
+	}
+	if out[1].Y != 5 {
+		t.Errorf("expected second bucket mean 5, got %v", out[1].Y)
+	}
+}
+
+func TestBucketize_LastBucketAbsorbsRemainder(t *testing.T) {
+	points := makePoints(10)
+	buckets := bucketize(points, 3)
+	if len(buckets) != 3 {
+		t.Fatalf("expected 3 buckets, got %d", len(buckets))
+	}
+	total := 0
+	for _, b := range buckets {
+		total += len(b)
+	}
+	if total != 10 {
+		t.Errorf("expected all 10 points distributed across buckets, got %d", total)
+	}
+}
+
+func TestBucketize_EmptyInput(t *testing.T) {
+	if buckets := bucketize(nil, 5); len(buckets) != 0 {
+		t.Errorf("expected no buckets from empty input, got %d", len(buckets))
+	}
+}
+
+func TestTriangleArea_Degenerate(t *testing.T) {
+	if area := triangleArea(0, 0, 1, 0, 2, 0); area != 0 {
+		t.Errorf("expected zero area for colinear points, got %v", area)
+	}
+}
+
+func TestAverage_EmptySlice(t *testing.T) {
+	x, y := average(nil)
+	if x != 0 || y != 0 {
+		t.Errorf("expected (0,0) for empty slice, got (%v,%v)", x, y)
+	}
+}
@@ -0,0 +1,49 @@
+// Package dsquery implements a small PromQL-inspired expression language for
+// querying a Dataset's ChartDataPoint series: selectors with label matchers,
+// range-vector lookback windows, aggregation/transform functions, and binary
+// arithmetic between series. It turns the dataset API from a plain CRUD
+// store into something closer to a real analytical query surface.
+package dsquery
+
+import "time"
+
+// Node is any parsed expression node.
+type Node interface {
+	node()
+}
+
+// VectorSelector selects a series by label matchers, e.g. `series{label="foo"}`.
+// When Range is non-nil (from a `[5m]` suffix), it's a range-vector selector
+// whose window is resolved per evaluation step rather than a single point.
+type VectorSelector struct {
+	Name     string
+	Matchers map[string]string
+	Range    *time.Duration
+}
+
+func (*VectorSelector) node() {}
+
+// NumberLiteral is a bare numeric constant.
+type NumberLiteral struct {
+	Value float64
+}
+
+func (*NumberLiteral) node() {}
+
+// Call is a function application, e.g. `avg_over_time(series{...}[5m])` or
+// `quantile(0.95, series{...}[5m])`.
+type Call struct {
+	Func string
+	Args []Node
+}
+
+func (*Call) node() {}
+
+// BinaryExpr is an arithmetic combination of two sub-expressions.
+type BinaryExpr struct {
+	Op  string
+	LHS Node
+	RHS Node
+}
+
+func (*BinaryExpr) node() {}
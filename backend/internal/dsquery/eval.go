@@ -0,0 +1,327 @@
+package dsquery
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"time"
+)
+
+// Sample is one raw (timestamp, value) observation for a named series, as
+// stored in a ChartDataPoint row.
+type Sample struct {
+	T time.Time
+	V float64
+}
+
+// SampleSource resolves a VectorSelector to the raw samples backing it.
+// ChartHandler implements this against GORM-loaded ChartDataPoints so the
+// evaluator itself stays storage-agnostic.
+type SampleSource interface {
+	Query(name string, matchers map[string]string) ([]Sample, error)
+}
+
+// Point is one evaluated (timestamp, value) pair in a result series.
+type Point struct {
+	T time.Time
+	V float64
+}
+
+// Series is a named, labeled result vector: at most one evaluated point per
+// step, with steps that have no samples in their window omitted.
+type Series struct {
+	Name   string
+	Labels map[string]string
+	Points []Point
+}
+
+// Range describes the step grid a query is evaluated over, mirroring
+// PromQL's range-query parameters.
+type Range struct {
+	Start time.Time
+	End   time.Time
+	Step  time.Duration
+}
+
+func (r Range) steps() []time.Time {
+	if r.Step <= 0 {
+		return []time.Time{r.End}
+	}
+	var out []time.Time
+	for t := r.Start; !t.After(r.End); t = t.Add(r.Step) {
+		out = append(out, t)
+	}
+	return out
+}
+
+// Eval evaluates node over the given range against src, producing one or
+// more result series.
+func Eval(node Node, src SampleSource, rng Range) ([]Series, error) {
+	switch n := node.(type) {
+	case *NumberLiteral:
+		points := make([]Point, 0, len(rng.steps()))
+		for _, t := range rng.steps() {
+			points = append(points, Point{T: t, V: n.Value})
+		}
+		return []Series{{Labels: map[string]string{}, Points: points}}, nil
+
+	case *VectorSelector:
+		return evalSelector(n, src, rng)
+
+	case *Call:
+		return evalCall(n, src, rng)
+
+	case *BinaryExpr:
+		return evalBinary(n, src, rng)
+
+	default:
+		return nil, fmt.Errorf("dsquery: unsupported node type %T", node)
+	}
+}
+
+func evalSelector(sel *VectorSelector, src SampleSource, rng Range) ([]Series, error) {
+	samples, err := src.Query(sel.Name, sel.Matchers)
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(samples, func(i, j int) bool { return samples[i].T.Before(samples[j].T) })
+
+	window := rng.Step
+	if sel.Range != nil {
+		window = *sel.Range
+	}
+
+	var points []Point
+	for _, t := range rng.steps() {
+		s := windowSamples(samples, t, window)
+		if len(s) == 0 {
+			continue
+		}
+		// An instant selector reports the latest sample at or before t; a
+		// bare range-vector selector (outside an _over_time call) does the
+		// same since it has no function to fold the window with.
+		points = append(points, Point{T: t, V: s[len(s)-1].V})
+	}
+
+	return []Series{{Name: sel.Name, Labels: sel.Matchers, Points: points}}, nil
+}
+
+// windowSamples returns samples in (t-window, t], the lookback window
+// PromQL range vectors use at each evaluation step.
+func windowSamples(samples []Sample, t time.Time, window time.Duration) []Sample {
+	lo := t.Add(-window)
+	var out []Sample
+	for _, s := range samples {
+		if s.T.After(lo) && !s.T.After(t) {
+			out = append(out, s)
+		}
+	}
+	return out
+}
+
+// rangeSeries evaluates the VectorSelector inside a *_over_time/rate/delta
+// call and returns, for each step, the raw samples in its lookback window
+// rather than a single resolved point.
+func rangeSeries(sel *VectorSelector, src SampleSource, rng Range) ([]string, map[string]string, [][]Sample, error) {
+	if sel.Range == nil {
+		return nil, nil, nil, fmt.Errorf("dsquery: %s() requires a range-vector argument like %s{...}[5m]", sel.Name, sel.Name)
+	}
+	samples, err := src.Query(sel.Name, sel.Matchers)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	sort.Slice(samples, func(i, j int) bool { return samples[i].T.Before(samples[j].T) })
+
+	steps := rng.steps()
+	windows := make([][]Sample, len(steps))
+	for i, t := range steps {
+		windows[i] = windowSamples(samples, t, *sel.Range)
+	}
+	return nil, sel.Matchers, windows, nil
+}
+
+func evalCall(call *Call, src SampleSource, rng Range) ([]Series, error) {
+	switch call.Func {
+	case "avg_over_time", "sum_over_time", "min_over_time", "max_over_time", "rate", "delta":
+		if len(call.Args) != 1 {
+			return nil, fmt.Errorf("dsquery: %s() takes exactly one argument", call.Func)
+		}
+		sel, ok := call.Args[0].(*VectorSelector)
+		if !ok {
+			return nil, fmt.Errorf("dsquery: %s() argument must be a range-vector selector", call.Func)
+		}
+		_, labels, windows, err := rangeSeries(sel, src, rng)
+		if err != nil {
+			return nil, err
+		}
+		steps := rng.steps()
+		points := make([]Point, 0, len(steps))
+		for i, t := range steps {
+			w := windows[i]
+			if len(w) == 0 {
+				continue
+			}
+			v, ok := aggregateWindow(call.Func, w)
+			if !ok {
+				continue
+			}
+			points = append(points, Point{T: t, V: v})
+		}
+		return []Series{{Name: sel.Name, Labels: labels, Points: points}}, nil
+
+	case "quantile":
+		if len(call.Args) != 2 {
+			return nil, fmt.Errorf("dsquery: quantile() takes exactly two arguments: quantile(q, series{...}[5m])")
+		}
+		qLit, ok := call.Args[0].(*NumberLiteral)
+		if !ok {
+			return nil, fmt.Errorf("dsquery: quantile()'s first argument must be a number")
+		}
+		sel, ok := call.Args[1].(*VectorSelector)
+		if !ok {
+			return nil, fmt.Errorf("dsquery: quantile()'s second argument must be a range-vector selector")
+		}
+		_, labels, windows, err := rangeSeries(sel, src, rng)
+		if err != nil {
+			return nil, err
+		}
+		steps := rng.steps()
+		points := make([]Point, 0, len(steps))
+		for i, t := range steps {
+			w := windows[i]
+			if len(w) == 0 {
+				continue
+			}
+			points = append(points, Point{T: t, V: quantileOf(qLit.Value, w)})
+		}
+		return []Series{{Name: sel.Name, Labels: labels, Points: points}}, nil
+
+	default:
+		return nil, fmt.Errorf("dsquery: unknown function %q", call.Func)
+	}
+}
+
+// aggregateWindow folds a lookback window down to a single value for the
+// *_over_time and rate/delta functions.
+func aggregateWindow(fn string, w []Sample) (float64, bool) {
+	switch fn {
+	case "avg_over_time":
+		sum := 0.0
+		for _, s := range w {
+			sum += s.V
+		}
+		return sum / float64(len(w)), true
+	case "sum_over_time":
+		sum := 0.0
+		for _, s := range w {
+			sum += s.V
+		}
+		return sum, true
+	case "min_over_time":
+		m := w[0].V
+		for _, s := range w {
+			if s.V < m {
+				m = s.V
+			}
+		}
+		return m, true
+	case "max_over_time":
+		m := w[0].V
+		for _, s := range w {
+			if s.V > m {
+				m = s.V
+			}
+		}
+		return m, true
+	case "delta":
+		return w[len(w)-1].V - w[0].V, true
+	case "rate":
+		if len(w) < 2 {
+			return 0, false
+		}
+		dt := w[len(w)-1].T.Sub(w[0].T).Seconds()
+		if dt <= 0 {
+			return 0, false
+		}
+		return (w[len(w)-1].V - w[0].V) / dt, true
+	default:
+		return 0, false
+	}
+}
+
+// quantileOf computes the q-th quantile (0 <= q <= 1) of a window by linear
+// interpolation between the two nearest ranks, as PromQL's
+// quantile_over_time does.
+func quantileOf(q float64, w []Sample) float64 {
+	vals := make([]float64, len(w))
+	for i, s := range w {
+		vals[i] = s.V
+	}
+	sort.Float64s(vals)
+	if q <= 0 {
+		return vals[0]
+	}
+	if q >= 1 {
+		return vals[len(vals)-1]
+	}
+	rank := q * float64(len(vals)-1)
+	lo := int(math.Floor(rank))
+	hi := int(math.Ceil(rank))
+	if lo == hi {
+		return vals[lo]
+	}
+	frac := rank - float64(lo)
+	return vals[lo]*(1-frac) + vals[hi]*frac
+}
+
+func evalBinary(expr *BinaryExpr, src SampleSource, rng Range) ([]Series, error) {
+	lhs, err := Eval(expr.LHS, src, rng)
+	if err != nil {
+		return nil, err
+	}
+	rhs, err := Eval(expr.RHS, src, rng)
+	if err != nil {
+		return nil, err
+	}
+	if len(lhs) != 1 || len(rhs) != 1 {
+		return nil, fmt.Errorf("dsquery: binary operators require single-series operands")
+	}
+
+	rhsByTime := make(map[int64]float64, len(rhs[0].Points))
+	for _, p := range rhs[0].Points {
+		rhsByTime[p.T.Unix()] = p.V
+	}
+
+	var points []Point
+	for _, p := range lhs[0].Points {
+		rv, ok := rhsByTime[p.T.Unix()]
+		if !ok {
+			continue
+		}
+		v, err := applyOp(expr.Op, p.V, rv)
+		if err != nil {
+			return nil, err
+		}
+		points = append(points, Point{T: p.T, V: v})
+	}
+
+	return []Series{{Name: lhs[0].Name, Labels: lhs[0].Labels, Points: points}}, nil
+}
+
+func applyOp(op string, a, b float64) (float64, error) {
+	switch op {
+	case "+":
+		return a + b, nil
+	case "-":
+		return a - b, nil
+	case "*":
+		return a * b, nil
+	case "/":
+		if b == 0 {
+			return 0, fmt.Errorf("dsquery: division by zero")
+		}
+		return a / b, nil
+	default:
+		return 0, fmt.Errorf("dsquery: unknown operator %q", op)
+	}
+}
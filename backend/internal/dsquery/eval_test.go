@@ -0,0 +1,192 @@
+package dsquery
+
+import (
+	"testing"
+	"time"
+)
+
+// fakeSource serves canned samples by series name, ignoring matchers.
+type fakeSource struct {
+	series map[string][]Sample
+}
+
+func (f fakeSource) Query(name string, _ map[string]string) ([]Sample, error) {
+	return f.series[name], nil
+}
+
+func mustParse(t *testing.T, query string) Node {
+	t.Helper()
+	node, err := Parse(query)
+	if err != nil {
+		t.Fatalf("Parse(%q) returned error: %v", query, err)
+	}
+	return node
+}
+
+func TestEval_NumberLiteral(t *testing.T) {
+	node := mustParse(t, "42")
+	rng := Range{Start: time.Unix(0, 0), End: time.Unix(0, 0), Step: 0}
+
+	series, err := Eval(node, fakeSource{}, rng)
+	if err != nil {
+		t.Fatalf("Eval returned error: %v", err)
+	}
+	if len(series) != 1 || len(series[0].Points) != 1 || series[0].Points[0].V != 42 {
+		t.Fatalf("got %+v, want a single series with one point valued 42", series)
+	}
+}
+
+func TestEval_VectorSelector_LatestSampleAtOrBeforeStep(t *testing.T) {
+	base := time.Unix(1000, 0)
+	src := fakeSource{series: map[string][]Sample{
+		"cpu": {
+			{T: base, V: 1},
+			{T: base.Add(10 * time.Second), V: 2},
+			{T: base.Add(20 * time.Second), V: 3},
+		},
+	}}
+
+	node := mustParse(t, `cpu`)
+	rng := Range{Start: base, End: base.Add(20 * time.Second), Step: 20 * time.Second}
+
+	series, err := Eval(node, src, rng)
+	if err != nil {
+		t.Fatalf("Eval returned error: %v", err)
+	}
+	if len(series) != 1 {
+		t.Fatalf("expected 1 series, got %d", len(series))
+	}
+	points := series[0].Points
+	if len(points) != 2 {
+		t.Fatalf("expected 2 evaluated points, got %d: %+v", len(points), points)
+	}
+	if points[len(points)-1].V != 3 {
+		t.Errorf("expected the final step to resolve to the latest sample, got %v", points[len(points)-1].V)
+	}
+}
+
+func TestEval_AvgOverTime(t *testing.T) {
+	base := time.Unix(1000, 0)
+	src := fakeSource{series: map[string][]Sample{
+		"cpu": {
+			{T: base, V: 10},
+			{T: base.Add(1 * time.Minute), V: 20},
+			{T: base.Add(2 * time.Minute), V: 30},
+		},
+	}}
+
+	node := mustParse(t, `avg_over_time(cpu[5m])`)
+	rng := Range{Start: base.Add(2 * time.Minute), End: base.Add(2 * time.Minute), Step: 0}
+
+	series, err := Eval(node, src, rng)
+	if err != nil {
+		t.Fatalf("Eval returned error: %v", err)
+	}
+	if len(series) != 1 || len(series[0].Points) != 1 {
+		t.Fatalf("got %+v, want a single series with one point", series)
+	}
+	if got := series[0].Points[0].V; got != 20 {
+		t.Errorf("avg_over_time = %v, want 20", got)
+	}
+}
+
+func TestEval_RateRequiresAtLeastTwoSamples(t *testing.T) {
+	base := time.Unix(1000, 0)
+	src := fakeSource{series: map[string][]Sample{
+		"cpu": {{T: base, V: 10}},
+	}}
+
+	node := mustParse(t, `rate(cpu[5m])`)
+	rng := Range{Start: base, End: base, Step: 0}
+
+	series, err := Eval(node, src, rng)
+	if err != nil {
+		t.Fatalf("Eval returned error: %v", err)
+	}
+	if len(series) != 1 || len(series[0].Points) != 0 {
+		t.Errorf("expected no points when rate() can't compute a delta, got %+v", series)
+	}
+}
+
+func TestEval_BinaryExpr_AlignsByTimestamp(t *testing.T) {
+	base := time.Unix(1000, 0)
+	src := fakeSource{series: map[string][]Sample{
+		"a": {{T: base, V: 10}},
+		"b": {{T: base, V: 4}},
+	}}
+
+	node := mustParse(t, `a / b`)
+	// Step must be > 0: windowSamples looks back (t-window, t], so a
+	// zero-width window excludes a sample sitting exactly on the step.
+	rng := Range{Start: base, End: base, Step: time.Minute}
+
+	series, err := Eval(node, src, rng)
+	if err != nil {
+		t.Fatalf("Eval returned error: %v", err)
+	}
+	if len(series) != 1 || len(series[0].Points) != 1 || series[0].Points[0].V != 2.5 {
+		t.Fatalf("got %+v, want a / b = 2.5", series)
+	}
+}
+
+func TestEval_BinaryExpr_DivisionByZero(t *testing.T) {
+	base := time.Unix(1000, 0)
+	src := fakeSource{series: map[string][]Sample{
+		"a": {{T: base, V: 10}},
+		"b": {{T: base, V: 0}},
+	}}
+
+	node := mustParse(t, `a / b`)
+	rng := Range{Start: base, End: base, Step: time.Minute}
+
+	if _, err := Eval(node, src, rng); err == nil {
+		t.Error("expected division by zero to return an error")
+	}
+}
+
+func TestQuantileOf(t *testing.T) {
+	base := time.Unix(1000, 0)
+	w := []Sample{{T: base, V: 1}, {T: base, V: 2}, {T: base, V: 3}, {T: base, V: 4}}
+
+	if got := quantileOf(0, w); got != 1 {
+		t.Errorf("quantileOf(0) = %v, want 1", got)
+	}
+	if got := quantileOf(1, w); got != 4 {
+		t.Errorf("quantileOf(1) = %v, want 4", got)
+	}
+	if got := quantileOf(0.5, w); got != 2.5 {
+		t.Errorf("quantileOf(0.5) = %v, want 2.5", got)
+	}
+}
+
+func TestApplyOp(t *testing.T) {
+	tests := []struct {
+		op      string
+		a, b    float64
+		want    float64
+		wantErr bool
+	}{
+		{"+", 2, 3, 5, false},
+		{"-", 5, 3, 2, false},
+		{"*", 4, 3, 12, false},
+		{"/", 10, 2, 5, false},
+		{"/", 10, 0, 0, true},
+		{"%", 10, 3, 0, true},
+	}
+	for _, tc := range tests {
+		got, err := applyOp(tc.op, tc.a, tc.b)
+		if tc.wantErr {
+			if err == nil {
+				t.Errorf("applyOp(%q, %v, %v) expected error, got none", tc.op, tc.a, tc.b)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("applyOp(%q, %v, %v) returned unexpected error: %v", tc.op, tc.a, tc.b, err)
+			continue
+		}
+		if got != tc.want {
+			t.Errorf("applyOp(%q, %v, %v) = %v, want %v", tc.op, tc.a, tc.b, got, tc.want)
+		}
+	}
+}
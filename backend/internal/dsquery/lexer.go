@@ -0,0 +1,145 @@
+package dsquery
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+type tokenKind int
+
+const (
+	tokEOF tokenKind = iota
+	tokIdent
+	tokNumber
+	tokString
+	tokDuration
+	tokLBrace
+	tokRBrace
+	tokLBracket
+	tokRBracket
+	tokLParen
+	tokRParen
+	tokComma
+	tokEquals
+	tokOp
+)
+
+type token struct {
+	kind tokenKind
+	text string
+}
+
+// lexer tokenizes a dsquery expression. It's hand-rolled rather than
+// regex-driven so duration literals (`5m`, `30s`) and quoted label values
+// tokenize unambiguously.
+type lexer struct {
+	input []rune
+	pos   int
+}
+
+func newLexer(input string) *lexer {
+	return &lexer{input: []rune(input)}
+}
+
+func (l *lexer) peekRune() rune {
+	if l.pos >= len(l.input) {
+		return 0
+	}
+	return l.input[l.pos]
+}
+
+func (l *lexer) skipSpace() {
+	for l.pos < len(l.input) && unicode.IsSpace(l.input[l.pos]) {
+		l.pos++
+	}
+}
+
+func (l *lexer) next() (token, error) {
+	l.skipSpace()
+	if l.pos >= len(l.input) {
+		return token{kind: tokEOF}, nil
+	}
+
+	r := l.input[l.pos]
+
+	switch r {
+	case '{':
+		l.pos++
+		return token{kind: tokLBrace, text: "{"}, nil
+	case '}':
+		l.pos++
+		return token{kind: tokRBrace, text: "}"}, nil
+	case '[':
+		l.pos++
+		return token{kind: tokLBracket, text: "["}, nil
+	case ']':
+		l.pos++
+		return token{kind: tokRBracket, text: "]"}, nil
+	case '(':
+		l.pos++
+		return token{kind: tokLParen, text: "("}, nil
+	case ')':
+		l.pos++
+		return token{kind: tokRParen, text: ")"}, nil
+	case ',':
+		l.pos++
+		return token{kind: tokComma, text: ","}, nil
+	case '=':
+		l.pos++
+		return token{kind: tokEquals, text: "="}, nil
+	case '+', '-', '*', '/':
+		l.pos++
+		return token{kind: tokOp, text: string(r)}, nil
+	case '"', '\'':
+		return l.lexString(r)
+	}
+
+	if unicode.IsDigit(r) {
+		return l.lexNumberOrDuration()
+	}
+	if unicode.IsLetter(r) || r == '_' {
+		return l.lexIdent()
+	}
+
+	return token{}, fmt.Errorf("dsquery: unexpected character %q at offset %d", r, l.pos)
+}
+
+func (l *lexer) lexString(quote rune) (token, error) {
+	l.pos++ // opening quote
+	start := l.pos
+	for l.pos < len(l.input) && l.input[l.pos] != quote {
+		l.pos++
+	}
+	if l.pos >= len(l.input) {
+		return token{}, fmt.Errorf("dsquery: unterminated string literal")
+	}
+	text := string(l.input[start:l.pos])
+	l.pos++ // closing quote
+	return token{kind: tokString, text: text}, nil
+}
+
+func (l *lexer) lexNumberOrDuration() (token, error) {
+	start := l.pos
+	for l.pos < len(l.input) && (unicode.IsDigit(l.input[l.pos]) || l.input[l.pos] == '.') {
+		l.pos++
+	}
+	// A trailing unit letter (s, m, h, d, w, y) makes this a duration
+	// literal like "5m" rather than a plain number.
+	unitStart := l.pos
+	for l.pos < len(l.input) && unicode.IsLetter(l.input[l.pos]) {
+		l.pos++
+	}
+	if l.pos > unitStart {
+		return token{kind: tokDuration, text: string(l.input[start:l.pos])}, nil
+	}
+	return token{kind: tokNumber, text: string(l.input[start:l.pos])}, nil
+}
+
+func (l *lexer) lexIdent() (token, error) {
+	start := l.pos
+	for l.pos < len(l.input) && (unicode.IsLetter(l.input[l.pos]) || unicode.IsDigit(l.input[l.pos]) || l.input[l.pos] == '_') {
+		l.pos++
+	}
+	return token{kind: tokIdent, text: strings.TrimSpace(string(l.input[start:l.pos]))}, nil
+}
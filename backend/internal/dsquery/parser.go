@@ -0,0 +1,244 @@
+package dsquery
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// Parse parses a dsquery expression string into an AST.
+func Parse(input string) (Node, error) {
+	p := &parser{lex: newLexer(input)}
+	if err := p.advance(); err != nil {
+		return nil, err
+	}
+	node, err := p.parseExpr()
+	if err != nil {
+		return nil, err
+	}
+	if p.tok.kind != tokEOF {
+		return nil, fmt.Errorf("dsquery: unexpected trailing token %q", p.tok.text)
+	}
+	return node, nil
+}
+
+type parser struct {
+	lex *lexer
+	tok token
+}
+
+func (p *parser) advance() error {
+	tok, err := p.lex.next()
+	if err != nil {
+		return err
+	}
+	p.tok = tok
+	return nil
+}
+
+func (p *parser) expect(kind tokenKind, what string) error {
+	if p.tok.kind != kind {
+		return fmt.Errorf("dsquery: expected %s, got %q", what, p.tok.text)
+	}
+	return p.advance()
+}
+
+// parseExpr handles the lowest-precedence binary operators (+ -), deferring
+// to parseTerm for * and /.
+func (p *parser) parseExpr() (Node, error) {
+	lhs, err := p.parseTerm()
+	if err != nil {
+		return nil, err
+	}
+	for p.tok.kind == tokOp && (p.tok.text == "+" || p.tok.text == "-") {
+		op := p.tok.text
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+		rhs, err := p.parseTerm()
+		if err != nil {
+			return nil, err
+		}
+		lhs = &BinaryExpr{Op: op, LHS: lhs, RHS: rhs}
+	}
+	return lhs, nil
+}
+
+func (p *parser) parseTerm() (Node, error) {
+	lhs, err := p.parseAtom()
+	if err != nil {
+		return nil, err
+	}
+	for p.tok.kind == tokOp && (p.tok.text == "*" || p.tok.text == "/") {
+		op := p.tok.text
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+		rhs, err := p.parseAtom()
+		if err != nil {
+			return nil, err
+		}
+		lhs = &BinaryExpr{Op: op, LHS: lhs, RHS: rhs}
+	}
+	return lhs, nil
+}
+
+func (p *parser) parseAtom() (Node, error) {
+	switch p.tok.kind {
+	case tokNumber:
+		v, err := strconv.ParseFloat(p.tok.text, 64)
+		if err != nil {
+			return nil, fmt.Errorf("dsquery: invalid number %q", p.tok.text)
+		}
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+		return &NumberLiteral{Value: v}, nil
+	case tokLParen:
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+		inner, err := p.parseExpr()
+		if err != nil {
+			return nil, err
+		}
+		if err := p.expect(tokRParen, ")"); err != nil {
+			return nil, err
+		}
+		return inner, nil
+	case tokIdent:
+		return p.parseIdentExpr()
+	default:
+		return nil, fmt.Errorf("dsquery: unexpected token %q", p.tok.text)
+	}
+}
+
+// parseIdentExpr disambiguates a function call (`avg_over_time(...)`) from a
+// bare vector selector (`series{label="foo"}[5m]`) by peeking at whether the
+// identifier is immediately followed by `(`.
+func (p *parser) parseIdentExpr() (Node, error) {
+	name := p.tok.text
+	if err := p.advance(); err != nil {
+		return nil, err
+	}
+
+	if p.tok.kind == tokLParen {
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+		var args []Node
+		if p.tok.kind != tokRParen {
+			for {
+				arg, err := p.parseCallArg()
+				if err != nil {
+					return nil, err
+				}
+				args = append(args, arg)
+				if p.tok.kind != tokComma {
+					break
+				}
+				if err := p.advance(); err != nil {
+					return nil, err
+				}
+			}
+		}
+		if err := p.expect(tokRParen, ")"); err != nil {
+			return nil, err
+		}
+		return &Call{Func: name, Args: args}, nil
+	}
+
+	return p.parseSelectorTail(name)
+}
+
+// parseCallArg allows a bare number (e.g. the quantile `q` argument) as well
+// as a full sub-expression.
+func (p *parser) parseCallArg() (Node, error) {
+	return p.parseExpr()
+}
+
+func (p *parser) parseSelectorTail(name string) (Node, error) {
+	sel := &VectorSelector{Name: name, Matchers: map[string]string{}}
+
+	if p.tok.kind == tokLBrace {
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+		for p.tok.kind != tokRBrace {
+			if p.tok.kind != tokIdent {
+				return nil, fmt.Errorf("dsquery: expected label name, got %q", p.tok.text)
+			}
+			label := p.tok.text
+			if err := p.advance(); err != nil {
+				return nil, err
+			}
+			if err := p.expect(tokEquals, "="); err != nil {
+				return nil, err
+			}
+			if p.tok.kind != tokString {
+				return nil, fmt.Errorf("dsquery: expected quoted label value, got %q", p.tok.text)
+			}
+			sel.Matchers[label] = p.tok.text
+			if err := p.advance(); err != nil {
+				return nil, err
+			}
+			if p.tok.kind == tokComma {
+				if err := p.advance(); err != nil {
+					return nil, err
+				}
+			}
+		}
+		if err := p.advance(); err != nil { // consume '}'
+			return nil, err
+		}
+	}
+
+	if p.tok.kind == tokLBracket {
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+		if p.tok.kind != tokDuration {
+			return nil, fmt.Errorf("dsquery: expected range duration, got %q", p.tok.text)
+		}
+		d, err := parseDuration(p.tok.text)
+		if err != nil {
+			return nil, err
+		}
+		sel.Range = &d
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+		if err := p.expect(tokRBracket, "]"); err != nil {
+			return nil, err
+		}
+	}
+
+	return sel, nil
+}
+
+// parseDuration parses range-vector literals like "5m", "30s", "2h", "1d", "1w".
+// time.ParseDuration handles s/m/h directly; d/w are translated to hours.
+func parseDuration(text string) (time.Duration, error) {
+	if d, err := time.ParseDuration(text); err == nil {
+		return d, nil
+	}
+	if len(text) < 2 {
+		return 0, fmt.Errorf("dsquery: invalid duration %q", text)
+	}
+	unit := text[len(text)-1]
+	numText := text[:len(text)-1]
+	n, err := strconv.ParseFloat(numText, 64)
+	if err != nil {
+		return 0, fmt.Errorf("dsquery: invalid duration %q", text)
+	}
+	switch unit {
+	case 'd':
+		return time.Duration(n * float64(24*time.Hour)), nil
+	case 'w':
+		return time.Duration(n * float64(7*24*time.Hour)), nil
+	case 'y':
+		return time.Duration(n * float64(365*24*time.Hour)), nil
+	default:
+		return 0, fmt.Errorf("dsquery: unrecognized duration unit in %q", text)
+	}
+}
@@ -0,0 +1,150 @@
+package filtering
+
+import (
+	"log"
+	"sync"
+	"time"
+
+	"github.com/bartosz/stocks-out-for-harambe/backend/internal/models"
+	"github.com/bartosz/stocks-out-for-harambe/backend/internal/storage"
+)
+
+// autoRefreshInterval is how often StartAutoRefresh sweeps expired filters
+// on its own. FilterHandler's CRUD/admin-read paths already call Refresh
+// on every mutation and on every list/get, but TextHandler and
+// SitemapHandler call Match directly against the cached matcher and never
+// refresh it themselves, so without this an expired filter would keep
+// matching indefinitely until someone happened to hit a Filter endpoint.
+const autoRefreshInterval = 30 * time.Second
+
+// trackedFilter is the subset of a models.Filter the Matcher needs, plus
+// the original row so Match can return it to callers.
+type trackedFilter struct {
+	source       *models.Filter
+	phrase       string
+	wholeWord    bool
+	irreversible bool
+	contexts     map[models.FilterContext]bool
+}
+
+// Engine owns the active Matcher for the current set of non-expired
+// filters and rebuilds it, atomically swapping the pointer, whenever the
+// filter set changes. It's the pluggable-subsystem-style wrapper used
+// elsewhere in this codebase (audit.Sink, search.Index, nlp.Provider)
+// applied to a matcher that happens to be built in-process rather than
+// dialed out to.
+type Engine struct {
+	mu      sync.RWMutex
+	matcher *Matcher
+}
+
+// NewEngine creates an Engine with an empty matcher. Call Refresh once
+// after construction (and again after every Filter mutation) to load it
+// from the database.
+func NewEngine() *Engine {
+	return &Engine{matcher: Build(nil)}
+}
+
+// StartAutoRefresh runs Refresh against db on a fixed timer for the life of
+// the process, independent of any Filter CRUD mutation or admin read. A
+// refresh failure is logged and retried on the next tick; the previous
+// matcher stays in place in the meantime.
+func (e *Engine) StartAutoRefresh(db *storage.Database) {
+	go func() {
+		ticker := time.NewTicker(autoRefreshInterval)
+		defer ticker.Stop()
+		for range ticker.C {
+			if err := e.Refresh(db); err != nil {
+				log.Printf("Warning: failed to auto-refresh filter engine: %v", err)
+			}
+		}
+	}()
+}
+
+// Refresh sweeps expired filters from the database, reloads the remaining
+// rows, and rebuilds the matcher. The expiry sweep is a plain delete run on
+// each call, so an expired filter keeps matching until the next Refresh: a
+// FilterHandler read or mutation, or StartAutoRefresh's next tick.
+func (e *Engine) Refresh(db *storage.Database) error {
+	if err := db.Where("expires_at IS NOT NULL AND expires_at < ?", time.Now()).Delete(&models.Filter{}).Error; err != nil {
+		return err
+	}
+
+	var rows []models.Filter
+	if err := db.Find(&rows).Error; err != nil {
+		return err
+	}
+
+	tracked := make([]*trackedFilter, len(rows))
+	for i := range rows {
+		row := rows[i]
+		contexts := make(map[models.FilterContext]bool, len(row.Context))
+		for _, c := range row.Context {
+			contexts[c] = true
+		}
+		tracked[i] = &trackedFilter{
+			source:       &row,
+			phrase:       row.Phrase,
+			wholeWord:    row.WholeWord,
+			irreversible: row.Irreversible,
+			contexts:     contexts,
+		}
+	}
+
+	matcher := Build(tracked)
+
+	e.mu.Lock()
+	e.matcher = matcher
+	e.mu.Unlock()
+
+	return nil
+}
+
+// Match scans text for every filter whose Context includes the given
+// pipeline, returning the underlying models.Filter for each distinct hit.
+// A filter matching more than once in the text is returned only once.
+func (e *Engine) Match(context models.FilterContext, text string) []models.Filter {
+	e.mu.RLock()
+	matcher := e.matcher
+	e.mu.RUnlock()
+
+	hits := matcher.Scan(text)
+	if len(hits) == 0 {
+		return nil
+	}
+
+	seen := make(map[*models.Filter]bool)
+	var result []models.Filter
+	for _, hit := range hits {
+		if !hit.Filter.contexts[context] {
+			continue
+		}
+		if seen[hit.Filter.source] {
+			continue
+		}
+		seen[hit.Filter.source] = true
+		result = append(result, *hit.Filter.source)
+	}
+	return result
+}
+
+// Irreversible reports whether any filter in matches is irreversible,
+// meaning the matched text should be dropped rather than just tagged.
+func Irreversible(matches []models.Filter) bool {
+	for _, f := range matches {
+		if f.Irreversible {
+			return true
+		}
+	}
+	return false
+}
+
+// Names returns the phrase of every filter in matches, the shape stored in
+// TextAnalysis.FilteredBy and URLExtraction.Metadata["filtered_by"].
+func Names(matches []models.Filter) []string {
+	names := make([]string, len(matches))
+	for i, f := range matches {
+		names[i] = f.Phrase
+	}
+	return names
+}
@@ -0,0 +1,147 @@
+// Package filtering implements phrase matching for models.Filter: an
+// Aho-Corasick automaton built from every active filter's phrase so that
+// checking a piece of text or a URL against the whole filter set is a
+// single linear scan rather than one regex/substring search per filter.
+package filtering
+
+import "strings"
+
+// node is one state in the Aho-Corasick trie/automaton.
+type node struct {
+	children map[rune]*node
+	fail     *node
+	// matches lists every filter whose phrase ends at this state, found
+	// either directly or via a fail-link chain built in build().
+	matches []*matchEntry
+}
+
+// matchEntry pairs a filter with the (lowercased) phrase length needed to
+// recover the matched span for whole-word boundary checks.
+type matchEntry struct {
+	filter      *trackedFilter
+	phraseRunes int
+}
+
+func newNode() *node {
+	return &node{children: make(map[rune]*node)}
+}
+
+// Matcher is a built Aho-Corasick automaton over a fixed set of filters.
+// It's immutable once built; Engine rebuilds and atomically swaps a new
+// Matcher whenever the underlying filters change.
+type Matcher struct {
+	root *node
+}
+
+// Build compiles an automaton over the given filters' phrases. Matching is
+// always case-insensitive; a filter's WholeWord flag is honored at match
+// time by checking the runes surrounding each hit.
+func Build(filters []*trackedFilter) *Matcher {
+	root := newNode()
+
+	for _, tf := range filters {
+		phrase := strings.ToLower(tf.phrase)
+		if phrase == "" {
+			continue
+		}
+		cur := root
+		runeCount := 0
+		for _, r := range phrase {
+			child, ok := cur.children[r]
+			if !ok {
+				child = newNode()
+				cur.children[r] = child
+			}
+			cur = child
+			runeCount++
+		}
+		cur.matches = append(cur.matches, &matchEntry{filter: tf, phraseRunes: runeCount})
+	}
+
+	// Breadth-first fail-link construction, standard Aho-Corasick.
+	queue := make([]*node, 0, len(root.children))
+	for _, child := range root.children {
+		child.fail = root
+		queue = append(queue, child)
+	}
+	for len(queue) > 0 {
+		cur := queue[0]
+		queue = queue[1:]
+		for r, child := range cur.children {
+			queue = append(queue, child)
+			failState := cur.fail
+			for failState != nil {
+				if next, ok := failState.children[r]; ok {
+					child.fail = next
+					break
+				}
+				failState = failState.fail
+			}
+			if child.fail == nil {
+				child.fail = root
+			}
+			child.matches = append(child.matches, child.fail.matches...)
+		}
+	}
+
+	return &Matcher{root: root}
+}
+
+// Hit is one matched occurrence of a filter's phrase within a piece of text.
+type Hit struct {
+	Filter *trackedFilter
+	Start  int
+	End    int
+}
+
+// Scan walks text once and returns every filter hit, skipping WholeWord
+// filters whose match isn't bounded on both sides by the string edge or a
+// rune other than an ASCII letter, digit, or underscore (see isWordRune).
+func (m *Matcher) Scan(text string) []Hit {
+	if m == nil || m.root == nil {
+		return nil
+	}
+
+	runes := []rune(strings.ToLower(text))
+	var hits []Hit
+
+	cur := m.root
+	for i, r := range runes {
+		for cur != m.root {
+			if _, ok := cur.children[r]; ok {
+				break
+			}
+			cur = cur.fail
+		}
+		if next, ok := cur.children[r]; ok {
+			cur = next
+		} else {
+			cur = m.root
+		}
+
+		for _, me := range cur.matches {
+			end := i + 1
+			start := end - me.phraseRunes
+			if me.filter.wholeWord && !isWordBounded(runes, start, end) {
+				continue
+			}
+			hits = append(hits, Hit{Filter: me.filter, Start: start, End: end})
+		}
+	}
+
+	return hits
+}
+
+func isWordBounded(runes []rune, start, end int) bool {
+	if start > 0 && isWordRune(runes[start-1]) {
+		return false
+	}
+	if end < len(runes) && isWordRune(runes[end]) {
+		return false
+	}
+	return true
+}
+
+func isWordRune(r rune) bool {
+	return (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9') || r == '_'
+}
@@ -0,0 +1,74 @@
+package filtering
+
+import "testing"
+
+func newTracked(phrase string, wholeWord bool) *trackedFilter {
+	return &trackedFilter{phrase: phrase, wholeWord: wholeWord}
+}
+
+func TestMatcher_Scan_BasicSubstring(t *testing.T) {
+	tf := newTracked("spam", false)
+	m := Build([]*trackedFilter{tf})
+
+	hits := m.Scan("this is spammy content")
+	if len(hits) != 1 {
+		t.Fatalf("expected 1 hit, got %d: %+v", len(hits), hits)
+	}
+	if hits[0].Filter != tf {
+		t.Errorf("hit matched wrong filter")
+	}
+}
+
+func TestMatcher_Scan_WholeWordBoundary(t *testing.T) {
+	tf := newTracked("spam", true)
+	m := Build([]*trackedFilter{tf})
+
+	if hits := m.Scan("this is spammy content"); len(hits) != 0 {
+		t.Errorf("whole_word filter should not match inside \"spammy\", got %d hits", len(hits))
+	}
+	if hits := m.Scan("this is spam, truly"); len(hits) != 1 {
+		t.Errorf("whole_word filter should match standalone \"spam\", got %d hits", len(hits))
+	}
+}
+
+func TestMatcher_Scan_CaseInsensitive(t *testing.T) {
+	tf := newTracked("Crypto", false)
+	m := Build([]*trackedFilter{tf})
+
+	hits := m.Scan("I love CRYPTO and cryptocurrency")
+	if len(hits) != 2 {
+		t.Fatalf("expected 2 case-insensitive hits, got %d", len(hits))
+	}
+}
+
+func TestMatcher_Scan_MultipleFiltersSharedPrefix(t *testing.T) {
+	tfShort := newTracked("cat", false)
+	tfLong := newTracked("catalog", false)
+	m := Build([]*trackedFilter{tfShort, tfLong})
+
+	hits := m.Scan("check the catalog")
+	families := map[*trackedFilter]int{}
+	for _, h := range hits {
+		families[h.Filter]++
+	}
+	if families[tfShort] != 1 {
+		t.Errorf("expected \"cat\" to match once inside \"catalog\", got %d", families[tfShort])
+	}
+	if families[tfLong] != 1 {
+		t.Errorf("expected \"catalog\" to match once, got %d", families[tfLong])
+	}
+}
+
+func TestMatcher_Scan_EmptyMatcher(t *testing.T) {
+	m := Build(nil)
+	if hits := m.Scan("anything at all"); hits != nil {
+		t.Errorf("expected no hits from an empty matcher, got %+v", hits)
+	}
+}
+
+func TestMatcher_Scan_NilMatcher(t *testing.T) {
+	var m *Matcher
+	if hits := m.Scan("anything"); hits != nil {
+		t.Errorf("expected nil matcher to return no hits, got %+v", hits)
+	}
+}
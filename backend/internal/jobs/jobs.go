@@ -0,0 +1,65 @@
+// Package jobs provides a bounded worker pool for cancellable background
+// work (batch text analysis, firecrawl-triggered ingestion, ...), so a
+// client that hangs up or an admin that wants to stop a runaway job has a
+// way to do so without waiting for the work to time out on its own.
+package jobs
+
+import (
+	"context"
+	"time"
+)
+
+// Status is a Job's current lifecycle state.
+type Status string
+
+const (
+	StatusQueued    Status = "queued"
+	StatusRunning   Status = "running"
+	StatusCompleted Status = "completed"
+	StatusFailed    Status = "failed"
+	StatusCancelled Status = "cancelled"
+)
+
+// CancelReason records why a cancelled job stopped.
+type CancelReason string
+
+const (
+	// ReasonClientCancel means the HTTP request the job was derived from
+	// was itself cancelled (e.g. the client hung up).
+	ReasonClientCancel CancelReason = "client_cancel"
+	// ReasonDeadline means the job's own timeout elapsed.
+	ReasonDeadline CancelReason = "deadline"
+	// ReasonAdmin means an operator cancelled the job via DELETE /jobs/{id}.
+	ReasonAdmin CancelReason = "admin"
+)
+
+// Spec describes a unit of work to submit to a Manager.
+type Spec struct {
+	// Kind labels the job for listing/observability (e.g. "batch_analyze",
+	// "firecrawl_ingest").
+	Kind string
+	// Timeout bounds how long Work may run before the Manager cancels its
+	// context with ReasonDeadline. Zero means no per-job timeout beyond
+	// whatever the submitting context already carries.
+	Timeout time.Duration
+	// Work is the job body. It must return promptly after ctx is done.
+	Work func(ctx context.Context) (interface{}, error)
+}
+
+// Result is what a Job produces, delivered on the channel Submit returns.
+type Result struct {
+	Value interface{}
+	Err   error
+}
+
+// Job is a snapshot of one submitted unit of work, as returned by List/Get.
+type Job struct {
+	ID           string       `json:"id"`
+	Kind         string       `json:"kind"`
+	Status       Status       `json:"status"`
+	CancelReason CancelReason `json:"cancel_reason,omitempty"`
+	Error        string       `json:"error,omitempty"`
+	CreatedAt    time.Time    `json:"created_at"`
+	StartedAt    *time.Time   `json:"started_at,omitempty"`
+	CompletedAt  *time.Time   `json:"completed_at,omitempty"`
+}
@@ -0,0 +1,263 @@
+package jobs
+
+import (
+	"context"
+	cryptorand "crypto/rand"
+	"encoding/hex"
+	"errors"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/bartosz/stocks-out-for-harambe/backend/internal/metrics"
+)
+
+// queueBufferMultiplier sizes the queue's buffer relative to worker count,
+// so a burst of submissions doesn't block callers while workers are busy.
+const queueBufferMultiplier = 4
+
+// ErrJobNotFound is returned by Cancel/Get when id isn't a known job.
+var ErrJobNotFound = errors.New("jobs: job not found")
+
+// contextKey namespaces values Manager stores on a job's context, mirroring
+// the pattern audit.WithActor/ActorFromContext uses for the audit actor.
+type contextKey string
+
+const jobIDContextKey contextKey = "job_id"
+
+// JobIDFromContext returns the ID of the job whose Work is currently
+// running on ctx, or "" if ctx wasn't derived from a Manager-submitted
+// job's context. Spec.Work implementations that need to publish progress
+// keyed by job ID (e.g. to a progress.Broker) retrieve it this way rather
+// than threading the ID through Spec.Work's own signature.
+func JobIDFromContext(ctx context.Context) string {
+	id, _ := ctx.Value(jobIDContextKey).(string)
+	return id
+}
+
+type queuedJob struct {
+	id   string
+	ctx  context.Context
+	spec Spec
+}
+
+// jobEntry is the Manager's bookkeeping for one submitted Job, guarded by
+// its own mutex so concurrent Cancel/SetDeadline calls and the worker
+// running the job don't race on the same Job snapshot.
+type jobEntry struct {
+	mu            sync.Mutex
+	job           Job
+	cancel        context.CancelFunc
+	deadlineTimer *time.Timer
+	resultCh      chan Result
+}
+
+func (e *jobEntry) snapshot() Job {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return e.job
+}
+
+// Manager runs submitted jobs on a bounded pool of workers sized by
+// concurrency, tracking each job's status in a registry so HTTP handlers
+// can list, inspect, and cancel them by ID.
+type Manager struct {
+	queue chan queuedJob
+
+	mu   sync.RWMutex
+	jobs map[string]*jobEntry
+}
+
+// NewManager creates a Manager and starts concurrency background workers.
+// concurrency is typically config.FirecrawlConfig.ConcurrentJobs.
+func NewManager(concurrency int) *Manager {
+	if concurrency <= 0 {
+		concurrency = 1
+	}
+
+	m := &Manager{
+		queue: make(chan queuedJob, concurrency*queueBufferMultiplier),
+		jobs:  make(map[string]*jobEntry),
+	}
+
+	for i := 0; i < concurrency; i++ {
+		go m.worker()
+	}
+
+	return m
+}
+
+// Submit enqueues spec and returns its Job ID plus a channel that receives
+// exactly one Result once the job finishes or is cancelled. ctx is
+// typically the submitting HTTP request's context.Context, so a client
+// hanging up cancels the job automatically; spec.Timeout additionally
+// bounds the job regardless of the caller's own context.
+func (m *Manager) Submit(ctx context.Context, spec Spec) (string, <-chan Result) {
+	id := newJobID()
+	jobCtx, cancel := context.WithCancel(context.WithValue(ctx, jobIDContextKey, id))
+
+	entry := &jobEntry{
+		job: Job{
+			ID:        id,
+			Kind:      spec.Kind,
+			Status:    StatusQueued,
+			CreatedAt: time.Now(),
+		},
+		cancel:   cancel,
+		resultCh: make(chan Result, 1),
+	}
+
+	if spec.Timeout > 0 {
+		entry.deadlineTimer = time.AfterFunc(spec.Timeout, func() {
+			m.cancel(id, ReasonDeadline)
+		})
+	}
+
+	m.mu.Lock()
+	m.jobs[id] = entry
+	m.mu.Unlock()
+
+	metrics.IncQueueDepth()
+	m.queue <- queuedJob{id: id, ctx: jobCtx, spec: spec}
+
+	return id, entry.resultCh
+}
+
+func (m *Manager) worker() {
+	for qj := range m.queue {
+		metrics.DecQueueDepth()
+		metrics.IncWorkersBusy()
+		m.run(qj)
+		metrics.DecWorkersBusy()
+	}
+}
+
+func (m *Manager) run(qj queuedJob) {
+	m.mu.RLock()
+	entry, ok := m.jobs[qj.id]
+	m.mu.RUnlock()
+	if !ok {
+		return
+	}
+
+	now := time.Now()
+	entry.mu.Lock()
+	entry.job.Status = StatusRunning
+	entry.job.StartedAt = &now
+	entry.mu.Unlock()
+
+	value, err := qj.spec.Work(qj.ctx)
+
+	completedAt := time.Now()
+	entry.mu.Lock()
+	entry.job.CompletedAt = &completedAt
+	if qj.ctx.Err() != nil {
+		// The context was cancelled, either by the deadline timer, an
+		// admin DELETE, or the submitting request's own context — the
+		// reason was already recorded by whichever of those set it.
+		entry.job.Status = StatusCancelled
+		if entry.job.CancelReason == "" {
+			entry.job.CancelReason = ReasonClientCancel
+		}
+		metrics.ObserveJobCancelled(string(entry.job.CancelReason))
+	} else if err != nil {
+		entry.job.Status = StatusFailed
+		entry.job.Error = err.Error()
+	} else {
+		entry.job.Status = StatusCompleted
+	}
+	if entry.deadlineTimer != nil {
+		entry.deadlineTimer.Stop()
+	}
+	entry.mu.Unlock()
+
+	entry.resultCh <- Result{Value: value, Err: err}
+	close(entry.resultCh)
+}
+
+// cancel cancels the job's context and records reason, if it hasn't already
+// finished or been cancelled.
+func (m *Manager) cancel(id string, reason CancelReason) error {
+	m.mu.RLock()
+	entry, ok := m.jobs[id]
+	m.mu.RUnlock()
+	if !ok {
+		return ErrJobNotFound
+	}
+
+	entry.mu.Lock()
+	alreadyTerminal := entry.job.Status == StatusCompleted || entry.job.Status == StatusFailed || entry.job.Status == StatusCancelled
+	if !alreadyTerminal {
+		entry.job.CancelReason = reason
+	}
+	entry.mu.Unlock()
+
+	entry.cancel()
+	return nil
+}
+
+// Cancel cancels a running or queued job as an admin action, for the
+// DELETE /api/v1/jobs/{id} endpoint.
+func (m *Manager) Cancel(id string) error {
+	return m.cancel(id, ReasonAdmin)
+}
+
+// SetDeadline replaces a job's deadline timer, stopping the previous one
+// first so a caller can extend or shorten a long-running job without
+// resubmitting it.
+func (m *Manager) SetDeadline(id string, d time.Duration) error {
+	m.mu.RLock()
+	entry, ok := m.jobs[id]
+	m.mu.RUnlock()
+	if !ok {
+		return ErrJobNotFound
+	}
+
+	entry.mu.Lock()
+	if entry.deadlineTimer != nil {
+		entry.deadlineTimer.Stop()
+	}
+	entry.deadlineTimer = time.AfterFunc(d, func() {
+		m.cancel(id, ReasonDeadline)
+	})
+	entry.mu.Unlock()
+
+	return nil
+}
+
+// Get returns a snapshot of one job's current state.
+func (m *Manager) Get(id string) (Job, error) {
+	m.mu.RLock()
+	entry, ok := m.jobs[id]
+	m.mu.RUnlock()
+	if !ok {
+		return Job{}, ErrJobNotFound
+	}
+	return entry.snapshot(), nil
+}
+
+// List returns a snapshot of every job the Manager knows about, most
+// recently created first.
+func (m *Manager) List() []Job {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	jobs := make([]Job, 0, len(m.jobs))
+	for _, entry := range m.jobs {
+		jobs = append(jobs, entry.snapshot())
+	}
+
+	sort.Slice(jobs, func(i, j int) bool {
+		return jobs[i].CreatedAt.After(jobs[j].CreatedAt)
+	})
+
+	return jobs
+}
+
+// newJobID draws a random 16-byte hex-encoded ID from crypto/rand, matching
+// the pattern established for audit request IDs.
+func newJobID() string {
+	var buf [16]byte
+	_, _ = cryptorand.Read(buf[:])
+	return hex.EncodeToString(buf[:])
+}
@@ -0,0 +1,24 @@
+package metrics
+
+import "github.com/prometheus/client_golang/prometheus"
+
+// AuditEventsDroppedTotal counts audit events discarded because the
+// Recorder's buffered channel was full, so an operator can tell when the
+// configured sink is too slow to keep up rather than silently losing
+// events.
+var AuditEventsDroppedTotal = prometheus.NewCounter(
+	prometheus.CounterOpts{
+		Name: "symbiot_audit_events_dropped_total",
+		Help: "Total number of audit events dropped due to a full buffer.",
+	},
+)
+
+func init() {
+	prometheus.MustRegister(AuditEventsDroppedTotal)
+}
+
+// ObserveAuditEventDropped records one audit event dropped under
+// back-pressure.
+func ObserveAuditEventDropped() {
+	AuditEventsDroppedTotal.Inc()
+}
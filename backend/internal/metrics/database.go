@@ -0,0 +1,93 @@
+// Package metrics (this file) exposes Prometheus collectors for the
+// database connection pool and query performance, fed from
+// observability.WatchPoolStats (periodic sql.DB.Stats() scrapes) and the
+// GORM tracing plugin (per-query spans).
+package metrics
+
+import (
+	"database/sql"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var (
+	// DBPoolOpenConnections is a gauge of the number of established
+	// connections, both in use and idle.
+	DBPoolOpenConnections = prometheus.NewGauge(
+		prometheus.GaugeOpts{
+			Name: "symbiot_db_pool_open_connections",
+			Help: "Number of established database connections (in use + idle).",
+		},
+	)
+
+	// DBPoolInUse is a gauge of connections currently in use.
+	DBPoolInUse = prometheus.NewGauge(
+		prometheus.GaugeOpts{
+			Name: "symbiot_db_pool_in_use",
+			Help: "Number of database connections currently in use.",
+		},
+	)
+
+	// DBPoolIdle is a gauge of idle connections.
+	DBPoolIdle = prometheus.NewGauge(
+		prometheus.GaugeOpts{
+			Name: "symbiot_db_pool_idle",
+			Help: "Number of idle database connections.",
+		},
+	)
+
+	// DBPoolWaitCountTotal counts connections that had to wait for a free
+	// slot in the pool.
+	DBPoolWaitCountTotal = prometheus.NewCounter(
+		prometheus.CounterOpts{
+			Name: "symbiot_db_pool_wait_count_total",
+			Help: "Total number of connections that had to wait for a free pool slot.",
+		},
+	)
+
+	// DBSlowQueriesTotal counts GORM queries at or above the configured
+	// SlowThreshold, keyed by table.
+	DBSlowQueriesTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "symbiot_db_slow_queries_total",
+			Help: "Total number of database queries at or above the slow-query threshold, by table.",
+		},
+		[]string{"table"},
+	)
+)
+
+func init() {
+	prometheus.MustRegister(
+		DBPoolOpenConnections,
+		DBPoolInUse,
+		DBPoolIdle,
+		DBPoolWaitCountTotal,
+		DBSlowQueriesTotal,
+	)
+}
+
+// lastWaitCount tracks sql.DBStats.WaitCount (itself already cumulative)
+// so repeated SetPoolStats calls can feed a monotonic Prometheus counter
+// instead of overwriting it like the gauges.
+var lastWaitCount int64
+
+// SetPoolStats updates the pool gauges (and advances the wait-count
+// counter) from a freshly scraped sql.DBStats.
+func SetPoolStats(stats sql.DBStats) {
+	DBPoolOpenConnections.Set(float64(stats.OpenConnections))
+	DBPoolInUse.Set(float64(stats.InUse))
+	DBPoolIdle.Set(float64(stats.Idle))
+
+	if delta := stats.WaitCount - lastWaitCount; delta > 0 {
+		DBPoolWaitCountTotal.Add(float64(delta))
+	}
+	lastWaitCount = stats.WaitCount
+}
+
+// ObserveSlowQuery records one query at or above the slow-query threshold.
+func ObserveSlowQuery(table string) {
+	if table == "" {
+		table = "unknown"
+	}
+	DBSlowQueriesTotal.WithLabelValues(table).Inc()
+}
@@ -0,0 +1,128 @@
+// Package metrics exposes Prometheus collectors for the extraction
+// subsystem. Collectors are fed from SitemapHandler where it creates and
+// updates URLExtraction/ExtractionSession rows, so a scrape reflects live
+// state without any extra DB queries. The models' GORM hooks and their
+// ToResponse/CalculateStatistics methods do not feed them.
+package metrics
+
+import (
+	"strconv"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var (
+	// ExtractionURLsTotal counts URLExtraction rows by their terminal or
+	// intermediate status, keyed by status.
+	ExtractionURLsTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "symbiot_extraction_urls_total",
+			Help: "Total number of URL extractions observed, by status.",
+		},
+		[]string{"status"},
+	)
+
+	// ExtractionRetriesTotal counts retry attempts, keyed by the error that
+	// triggered them and the backoff strategy used.
+	ExtractionRetriesTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "symbiot_extraction_retries_total",
+			Help: "Total number of extraction retries, by error type and strategy.",
+		},
+		[]string{"error_type", "strategy"},
+	)
+
+	// ExtractionProcessingSeconds observes per-URL processing time, fed from
+	// URLExtraction.ProcessingTimeMs.
+	ExtractionProcessingSeconds = prometheus.NewHistogram(
+		prometheus.HistogramOpts{
+			Name:    "symbiot_extraction_processing_seconds",
+			Help:    "Per-URL extraction processing time in seconds.",
+			Buckets: prometheus.DefBuckets,
+		},
+	)
+
+	// ExtractionSessionDurationSeconds observes total session wall-clock
+	// duration, fed once a session reaches a terminal status.
+	ExtractionSessionDurationSeconds = prometheus.NewHistogram(
+		prometheus.HistogramOpts{
+			Name:    "symbiot_extraction_session_duration_seconds",
+			Help:    "Wall-clock duration of completed extraction sessions, in seconds.",
+			Buckets: []float64{1, 5, 15, 30, 60, 120, 300, 600, 1800, 3600},
+		},
+	)
+
+	// ExtractionHTTPStatusTotal counts the HTTP status codes returned by
+	// extracted URLs.
+	ExtractionHTTPStatusTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "symbiot_extraction_http_status_total",
+			Help: "Total HTTP status codes seen while extracting URLs.",
+		},
+		[]string{"code"},
+	)
+
+	// ExtractionSessionsInProgress is a gauge of extraction sessions
+	// currently in the "in_progress" status.
+	ExtractionSessionsInProgress = prometheus.NewGauge(
+		prometheus.GaugeOpts{
+			Name: "symbiot_extraction_sessions_in_progress",
+			Help: "Number of extraction sessions currently in progress.",
+		},
+	)
+)
+
+func init() {
+	prometheus.MustRegister(
+		ExtractionURLsTotal,
+		ExtractionRetriesTotal,
+		ExtractionProcessingSeconds,
+		ExtractionSessionDurationSeconds,
+		ExtractionHTTPStatusTotal,
+		ExtractionSessionsInProgress,
+	)
+}
+
+// ObserveURLStatus records a URLExtraction transitioning to status.
+func ObserveURLStatus(status string) {
+	ExtractionURLsTotal.WithLabelValues(status).Inc()
+}
+
+// ObserveRetry records a retry attempt for the given error type and backoff
+// strategy. Empty values are recorded as "unknown" so a dashboard doesn't
+// silently drop unlabeled samples.
+func ObserveRetry(errorType, strategy string) {
+	if errorType == "" {
+		errorType = "unknown"
+	}
+	if strategy == "" {
+		strategy = "unknown"
+	}
+	ExtractionRetriesTotal.WithLabelValues(errorType, strategy).Inc()
+}
+
+// ObserveProcessingTimeMs records a completed URL's processing time.
+func ObserveProcessingTimeMs(ms int) {
+	ExtractionProcessingSeconds.Observe(float64(ms) / 1000.0)
+}
+
+// ObserveSessionDurationSeconds records a completed session's total duration.
+func ObserveSessionDurationSeconds(seconds float64) {
+	ExtractionSessionDurationSeconds.Observe(seconds)
+}
+
+// ObserveHTTPStatus records an HTTP status code returned while extracting a
+// URL.
+func ObserveHTTPStatus(code int) {
+	ExtractionHTTPStatusTotal.WithLabelValues(strconv.Itoa(code)).Inc()
+}
+
+// IncSessionsInProgress marks one more session as in progress.
+func IncSessionsInProgress() {
+	ExtractionSessionsInProgress.Inc()
+}
+
+// DecSessionsInProgress marks one fewer session as in progress.
+func DecSessionsInProgress() {
+	ExtractionSessionsInProgress.Dec()
+}
@@ -0,0 +1,46 @@
+// Package metrics (this file) exposes Prometheus collectors for inbound
+// HTTP traffic, fed from observability.GinMiddleware so every request
+// handled by the router is counted without each handler instrumenting
+// itself.
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var (
+	// HTTPRequestsTotal counts requests by method, route template (gin's
+	// c.FullPath(), not the raw path, so /charts/:id stays one series
+	// regardless of id), and response status.
+	HTTPRequestsTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "symbiot_http_requests_total",
+			Help: "Total number of HTTP requests, by method, route, and status.",
+		},
+		[]string{"method", "route", "status"},
+	)
+
+	// HTTPRequestDurationSeconds observes request latency by method and
+	// route template.
+	HTTPRequestDurationSeconds = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "symbiot_http_request_duration_seconds",
+			Help:    "HTTP request latency in seconds, by method and route.",
+			Buckets: prometheus.DefBuckets,
+		},
+		[]string{"method", "route"},
+	)
+)
+
+func init() {
+	prometheus.MustRegister(
+		HTTPRequestsTotal,
+		HTTPRequestDurationSeconds,
+	)
+}
+
+// ObserveHTTPRequest records one completed request's status and latency.
+func ObserveHTTPRequest(method, route, status string, seconds float64) {
+	HTTPRequestsTotal.WithLabelValues(method, route, status).Inc()
+	HTTPRequestDurationSeconds.WithLabelValues(method, route).Observe(seconds)
+}
@@ -0,0 +1,70 @@
+// Package metrics (this file) exposes Prometheus collectors for
+// internal/jobs' Manager, fed directly from Submit and the worker loop
+// (which also counts cancellations) so a scrape reflects live queue and
+// worker state without any extra bookkeeping query.
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var (
+	// JobsQueueDepth is a gauge of jobs submitted but not yet picked up by a
+	// worker.
+	JobsQueueDepth = prometheus.NewGauge(
+		prometheus.GaugeOpts{
+			Name: "symbiot_jobs_queue_depth",
+			Help: "Number of jobs waiting for a free worker.",
+		},
+	)
+
+	// JobsWorkersBusy is a gauge of workers currently executing a job.
+	JobsWorkersBusy = prometheus.NewGauge(
+		prometheus.GaugeOpts{
+			Name: "symbiot_jobs_workers_busy",
+			Help: "Number of job-manager workers currently executing a job.",
+		},
+	)
+
+	// JobsCancellationsTotal counts cancelled jobs, keyed by reason.
+	JobsCancellationsTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "symbiot_jobs_cancellations_total",
+			Help: "Total number of cancelled jobs, by reason.",
+		},
+		[]string{"reason"},
+	)
+)
+
+func init() {
+	prometheus.MustRegister(
+		JobsQueueDepth,
+		JobsWorkersBusy,
+		JobsCancellationsTotal,
+	)
+}
+
+// IncQueueDepth marks one more job as queued.
+func IncQueueDepth() {
+	JobsQueueDepth.Inc()
+}
+
+// DecQueueDepth marks one fewer job as queued.
+func DecQueueDepth() {
+	JobsQueueDepth.Dec()
+}
+
+// IncWorkersBusy marks one more worker as executing a job.
+func IncWorkersBusy() {
+	JobsWorkersBusy.Inc()
+}
+
+// DecWorkersBusy marks one fewer worker as executing a job.
+func DecWorkersBusy() {
+	JobsWorkersBusy.Dec()
+}
+
+// ObserveJobCancelled records a job cancelled for the given reason.
+func ObserveJobCancelled(reason string) {
+	JobsCancellationsTotal.WithLabelValues(reason).Inc()
+}
@@ -3,6 +3,7 @@ package models
 import (
 	"time"
 
+	"github.com/bartosz/stocks-out-for-harambe/backend/internal/audit"
 	"github.com/jinzhu/gorm"
 )
 
@@ -126,4 +127,16 @@ func (d *Dataset) BeforeCreate(scope *gorm.Scope) error {
 		d.Metadata = make(map[string]string)
 	}
 	return nil
-}
\ No newline at end of file
+}
+
+// AfterUpdate is declared against jinzhu/gorm's v1 callback signature
+// (scope *gorm.Scope), but internal/storage runs gorm.io/gorm v2, which
+// resolves hooks via AfterUpdate(tx *gorm.DB) error from its own package —
+// a v1-shaped method never satisfies that interface, so this never runs.
+// ChartHandler.UpdateDataset calls audit.Record directly with a real
+// before/after diff instead; create and delete are audited the same way,
+// from ChartHandler.CreateDataset and DeleteDataset.
+func (d *Dataset) AfterUpdate(scope *gorm.Scope) error {
+	audit.RecordFromHook("update", "dataset", d.ID, nil, d)
+	return nil
+}
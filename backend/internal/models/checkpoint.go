@@ -0,0 +1,38 @@
+package models
+
+import (
+	"time"
+
+	"github.com/jinzhu/gorm"
+)
+
+// ExtractionCheckpoint records where an ExtractionSession last made
+// verifiable progress, so a process restart can resume exactly where it
+// stopped instead of re-crawling from chunk zero. One session has at most
+// one live checkpoint, which is overwritten in place as progress advances.
+type ExtractionCheckpoint struct {
+	ID                  uint      `json:"id" gorm:"primary_key"`
+	SessionID           string    `json:"session_id" gorm:"type:uuid;not null;unique_index"`
+	LastChunkNumber     int       `json:"last_chunk_number" gorm:"default:0"`
+	LastPositionInChunk int       `json:"last_position_in_chunk" gorm:"default:0"`
+	InFlightURLs        []string  `json:"in_flight_urls" gorm:"type:jsonb"`
+	CheckpointedAt      time.Time `json:"checkpointed_at"`
+	CreatedAt           time.Time `json:"created_at"`
+	UpdatedAt           time.Time `json:"updated_at"`
+}
+
+// TableName sets the table name for ExtractionCheckpoint
+func (ExtractionCheckpoint) TableName() string {
+	return "extraction_checkpoints"
+}
+
+// BeforeCreate sets default values before creating a checkpoint
+func (ec *ExtractionCheckpoint) BeforeCreate(scope *gorm.Scope) error {
+	if ec.InFlightURLs == nil {
+		ec.InFlightURLs = []string{}
+	}
+	if ec.CheckpointedAt.IsZero() {
+		ec.CheckpointedAt = time.Now()
+	}
+	return nil
+}
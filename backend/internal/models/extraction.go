@@ -3,6 +3,7 @@ package models
 import (
 	"time"
 
+	"github.com/bartosz/stocks-out-for-harambe/backend/internal/urlhash"
 	"github.com/jinzhu/gorm"
 )
 
@@ -14,6 +15,7 @@ const (
 	ExtractionStatusCompleted  ExtractionStatus = "completed"
 	ExtractionStatusFailed     ExtractionStatus = "failed"
 	ExtractionStatusCancelled  ExtractionStatus = "cancelled"
+	ExtractionStatusPaused     ExtractionStatus = "paused"
 )
 
 // ExtractionURLStatus represents the status of a URL extraction
@@ -51,9 +53,9 @@ type ExtractionSession struct {
 // URLExtraction represents individual URL extraction records
 type URLExtraction struct {
 	ExtractionID    string              `json:"extraction_id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
-	SessionID       string              `json:"session_id" gorm:"type:uuid;not null;index"`
+	SessionID       string              `json:"session_id" gorm:"type:uuid;not null;index;unique_index:idx_url_extractions_session_hash"`
 	URL             string              `json:"url" gorm:"type:text;not null"`
-	URLHash         string              `json:"url_hash" gorm:"size:64;not null;index"`
+	URLHash         string              `json:"url_hash" gorm:"size:64;not null;unique_index:idx_url_extractions_session_hash"`
 	ChunkNumber     int                 `json:"chunk_number" gorm:"not null"`
 	PositionInChunk int                 `json:"position_in_chunk" gorm:"not null"`
 	Status          ExtractionURLStatus `json:"status" gorm:"type:extraction_url_status;default:'pending'"`
@@ -92,6 +94,7 @@ type ExtractionRetry struct {
 	ErrorMessage     string              `json:"error_message,omitempty" gorm:"type:text"`
 	ProcessingTimeMs *int                `json:"processing_time_ms,omitempty"`
 	HTTPStatus       *int                `json:"http_status,omitempty"`
+	RetryAfterMs     *int                `json:"retry_after_ms,omitempty"`
 	RetryStrategy    string              `json:"retry_strategy,omitempty"`
 	CreatedAt        time.Time           `json:"created_at"`
 
@@ -200,6 +203,7 @@ type ExtractionRetryResponse struct {
 	ErrorMessage     string              `json:"error_message,omitempty"`
 	ProcessingTimeMs *int                `json:"processing_time_ms,omitempty"`
 	HTTPStatus       *int                `json:"http_status,omitempty"`
+	RetryAfterMs     *int                `json:"retry_after_ms,omitempty"`
 	RetryStrategy    string              `json:"retry_strategy,omitempty"`
 	CreatedAt        time.Time           `json:"created_at"`
 }
@@ -300,6 +304,7 @@ func (er *ExtractionRetry) ToResponse() ExtractionRetryResponse {
 		ErrorMessage:     er.ErrorMessage,
 		ProcessingTimeMs: er.ProcessingTimeMs,
 		HTTPStatus:       er.HTTPStatus,
+		RetryAfterMs:     er.RetryAfterMs,
 		RetryStrategy:    er.RetryStrategy,
 		CreatedAt:        er.CreatedAt,
 	}
@@ -340,7 +345,18 @@ func (es *ExtractionSession) CalculateStatistics() ExtractionStatistics {
 	return stats
 }
 
-// BeforeCreate sets default values before creating extraction session
+// BeforeCreate sets default values before creating extraction session.
+//
+// This and the hooks below it are declared against github.com/jinzhu/gorm's
+// v1 callback signature (scope *gorm.Scope), but internal/storage runs
+// gorm.io/gorm v2, which resolves hooks via BeforeCreate(tx *gorm.DB) error
+// from its own package — a v1-shaped method never satisfies that interface,
+// so none of these actually run. They used to also emit the
+// symbiot_extraction_* metrics; that's been moved to the handlers that
+// really mutate these rows (see sitemap_handler.go) since a silently-dead
+// hook is a worse failure mode than a few extra call sites. Left here
+// unconverted since the default-value behavior is a separate, pre-existing
+// gap from the metrics one this fixes.
 func (es *ExtractionSession) BeforeCreate(scope *gorm.Scope) error {
 	if es.Metadata == nil {
 		es.Metadata = make(map[string]interface{})
@@ -356,10 +372,11 @@ func (ue *URLExtraction) BeforeCreate(scope *gorm.Scope) error {
 	if ue.MaxRetries == 0 {
 		ue.MaxRetries = 3
 	}
-	// Generate URL hash if not provided
+	// Fill URLHash from urlhash.Hash when empty. Like the other hooks in
+	// this file it is not invoked by gorm v2, so callers creating rows set
+	// URLHash themselves, as SitemapHandler does.
 	if ue.URLHash == "" {
-		// This would call a hash function - simplified for now
-		ue.URLHash = "hash_" + ue.URL[:10] // Simplified implementation
+		ue.URLHash = urlhash.Hash(ue.URL)
 	}
 	return nil
 }
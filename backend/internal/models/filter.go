@@ -0,0 +1,112 @@
+package models
+
+import (
+	"time"
+
+	"github.com/bartosz/stocks-out-for-harambe/backend/internal/audit"
+	"github.com/jinzhu/gorm"
+)
+
+// FilterContext is one of the pipelines a Filter's phrase is checked
+// against, mirroring Mastodon's filter context concept.
+type FilterContext string
+
+const (
+	FilterContextAnalysis   FilterContext = "analysis"
+	FilterContextKeyword    FilterContext = "keyword"
+	FilterContextEntity     FilterContext = "entity"
+	FilterContextExtraction FilterContext = "extraction"
+)
+
+// Filter is a phrase to match against incoming text, keywords/entities
+// extracted from it, or URLs submitted for extraction. Irreversible
+// filters drop the matching text server-side before it's ever persisted;
+// non-irreversible ones let it through but tag the resulting record's
+// filtered_by so the UI can hide or collapse it. Expired filters are deleted
+// by filtering.Engine.Refresh, which runs on Filter reads and mutations and
+// on StartAutoRefresh's timer.
+type Filter struct {
+	ID           uint            `json:"id" gorm:"primary_key"`
+	Phrase       string          `json:"phrase" gorm:"not null;index"`
+	Context      []FilterContext `json:"context" gorm:"type:jsonb"`
+	WholeWord    bool            `json:"whole_word" gorm:"default:true"`
+	Irreversible bool            `json:"irreversible" gorm:"default:false"`
+	ExpiresAt    *time.Time      `json:"expires_at,omitempty"`
+	CreatedAt    time.Time       `json:"created_at"`
+	UpdatedAt    time.Time       `json:"updated_at"`
+}
+
+// CreateFilterRequest represents a request to create a new Filter.
+type CreateFilterRequest struct {
+	Phrase       string          `json:"phrase" binding:"required"`
+	Context      []FilterContext `json:"context" binding:"required"`
+	WholeWord    *bool           `json:"whole_word"`
+	Irreversible bool            `json:"irreversible"`
+	ExpiresAt    *time.Time      `json:"expires_at"`
+}
+
+// UpdateFilterRequest represents a request to update a Filter. Zero values
+// are left untouched, matching UpdateDatasetRequest's convention; use
+// ExpiresAt's presence in the raw JSON to clear an expiry is not supported
+// since Go can't distinguish "absent" from "null" here without a wrapper
+// type, so clearing an expiry requires deleting and recreating the filter.
+type UpdateFilterRequest struct {
+	Phrase       string          `json:"phrase"`
+	Context      []FilterContext `json:"context"`
+	WholeWord    *bool           `json:"whole_word"`
+	Irreversible *bool           `json:"irreversible"`
+	ExpiresAt    *time.Time      `json:"expires_at"`
+}
+
+// FilterResponse represents the response format for a Filter.
+type FilterResponse struct {
+	ID           uint            `json:"id"`
+	Phrase       string          `json:"phrase"`
+	Context      []FilterContext `json:"context"`
+	WholeWord    bool            `json:"whole_word"`
+	Irreversible bool            `json:"irreversible"`
+	ExpiresAt    *time.Time      `json:"expires_at,omitempty"`
+	CreatedAt    time.Time       `json:"created_at"`
+	UpdatedAt    time.Time       `json:"updated_at"`
+}
+
+// ToResponse converts a Filter to a FilterResponse.
+func (f *Filter) ToResponse() FilterResponse {
+	return FilterResponse{
+		ID:           f.ID,
+		Phrase:       f.Phrase,
+		Context:      f.Context,
+		WholeWord:    f.WholeWord,
+		Irreversible: f.Irreversible,
+		ExpiresAt:    f.ExpiresAt,
+		CreatedAt:    f.CreatedAt,
+		UpdatedAt:    f.UpdatedAt,
+	}
+}
+
+// HasContext reports whether the filter applies to the given pipeline.
+func (f *Filter) HasContext(context FilterContext) bool {
+	for _, c := range f.Context {
+		if c == context {
+			return true
+		}
+	}
+	return false
+}
+
+// AfterUpdate is declared against jinzhu/gorm's v1 callback signature
+// (scope *gorm.Scope), but internal/storage runs gorm.io/gorm v2, which
+// resolves hooks via AfterUpdate(tx *gorm.DB) error from its own package —
+// a v1-shaped method never satisfies that interface, so this never runs.
+// FilterHandler.UpdateFilter calls audit.Record directly with a real
+// before/after diff instead; create and delete are audited the same way,
+// from FilterHandler.CreateFilter and DeleteFilter.
+func (f *Filter) AfterUpdate(scope *gorm.Scope) error {
+	audit.RecordFromHook("update", "filter", f.ID, nil, f)
+	return nil
+}
+
+// TableName sets the table name for Filter.
+func (Filter) TableName() string {
+	return "filters"
+}
@@ -0,0 +1,22 @@
+package models
+
+import "time"
+
+// KeywordDocFreq tracks how many TextAnalysis documents a given word has
+// appeared in, across the whole corpus, so keyword extraction can compute
+// TF-IDF relevance instead of raw in-document frequency. It's updated only
+// by TF-IDF extraction (keyword_mode "tfidf"), which increments every
+// distinct word of the analyzed text; analyses in the default keyword mode
+// leave it unchanged.
+type KeywordDocFreq struct {
+	ID           uint      `json:"id" gorm:"primary_key"`
+	Word         string    `json:"word" gorm:"not null;unique_index"`
+	DocFrequency int       `json:"doc_frequency" gorm:"not null;default:0"`
+	CreatedAt    time.Time `json:"created_at"`
+	UpdatedAt    time.Time `json:"updated_at"`
+}
+
+// TableName sets the table name for KeywordDocFreq
+func (KeywordDocFreq) TableName() string {
+	return "keyword_doc_freqs"
+}
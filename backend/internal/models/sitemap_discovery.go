@@ -0,0 +1,32 @@
+package models
+
+import (
+	"time"
+
+	"github.com/jinzhu/gorm"
+)
+
+// SitemapDiscovery persists the tree produced by a sitemapdiscovery.Discover
+// run, so StartBatchExtraction can reference it by ID instead of forcing
+// callers to re-send thousands of discovered URLs in a request body.
+type SitemapDiscovery struct {
+	DiscoveryID string                 `json:"discovery_id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	BaseURL     string                 `json:"base_url" gorm:"type:text;not null"`
+	Tree        map[string]interface{} `json:"tree" gorm:"type:jsonb"`
+	TotalURLs   int                    `json:"total_urls" gorm:"default:0"`
+	CreatedAt   time.Time              `json:"created_at"`
+	UpdatedAt   time.Time              `json:"updated_at"`
+}
+
+// TableName sets the table name for SitemapDiscovery
+func (SitemapDiscovery) TableName() string {
+	return "sitemap_discoveries"
+}
+
+// BeforeCreate sets default values before creating a discovery record
+func (sd *SitemapDiscovery) BeforeCreate(scope *gorm.Scope) error {
+	if sd.Tree == nil {
+		sd.Tree = map[string]interface{}{}
+	}
+	return nil
+}
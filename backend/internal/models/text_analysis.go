@@ -3,22 +3,27 @@ package models
 import (
 	"time"
 
+	"github.com/bartosz/stocks-out-for-harambe/backend/internal/textanalysis"
 	"github.com/jinzhu/gorm"
 )
 
 // TextAnalysis represents a text analysis record
 type TextAnalysis struct {
-	ID         uint                 `json:"id" gorm:"primary_key"`
-	Text       string               `json:"text" gorm:"type:text;not null"`
-	Language   string               `json:"language" gorm:"default:'en'"`
-	Sentiment  SentimentAnalysis    `json:"sentiment" gorm:"embedded"`
-	Keywords   []Keyword            `json:"keywords,omitempty" gorm:"foreignkey:AnalysisID"`
-	Entities   []Entity             `json:"entities,omitempty" gorm:"foreignkey:AnalysisID"`
+	ID          uint                `json:"id" gorm:"primary_key"`
+	Text        string              `json:"text" gorm:"type:text;not null"`
+	Language    string              `json:"language" gorm:"default:'en'"`
+	Sentiment   SentimentAnalysis   `json:"sentiment" gorm:"embedded"`
+	Keywords    []Keyword           `json:"keywords,omitempty" gorm:"foreignkey:AnalysisID"`
+	Entities    []Entity            `json:"entities,omitempty" gorm:"foreignkey:AnalysisID"`
 	Readability ReadabilityAnalysis `json:"readability" gorm:"embedded"`
-	Metadata   map[string]string    `json:"metadata" gorm:"type:jsonb"`
-	IsPublic   bool                 `json:"is_public" gorm:"default:false"`
-	CreatedAt  time.Time            `json:"created_at"`
-	UpdatedAt  time.Time            `json:"updated_at"`
+	Metadata    map[string]string   `json:"metadata" gorm:"type:jsonb"`
+	IsPublic    bool                `json:"is_public" gorm:"default:false"`
+	// FilteredBy lists the phrases of any non-irreversible models.Filter
+	// that matched this analysis's text, keywords, or entities, so the UI
+	// can hide or collapse it. Empty when no filter matched.
+	FilteredBy []string  `json:"filtered_by,omitempty" gorm:"type:jsonb"`
+	CreatedAt  time.Time `json:"created_at"`
+	UpdatedAt  time.Time `json:"updated_at"`
 }
 
 // SentimentAnalysis represents sentiment analysis results
@@ -30,23 +35,23 @@ type SentimentAnalysis struct {
 
 // Keyword represents a keyword extracted from text
 type Keyword struct {
-	ID         uint    `json:"id" gorm:"primary_key"`
-	AnalysisID uint    `json:"analysis_id" gorm:"index"`
-	Word       string  `json:"word" gorm:"not null"`
-	Frequency  int     `json:"frequency" gorm:"not null"`
-	Relevance  float64 `json:"relevance" gorm:"not null"`
+	ID         uint      `json:"id" gorm:"primary_key"`
+	AnalysisID uint      `json:"analysis_id" gorm:"index"`
+	Word       string    `json:"word" gorm:"not null"`
+	Frequency  int       `json:"frequency" gorm:"not null"`
+	Relevance  float64   `json:"relevance" gorm:"not null"`
 	CreatedAt  time.Time `json:"created_at"`
 }
 
 // Entity represents a named entity extracted from text
 type Entity struct {
-	ID         uint    `json:"id" gorm:"primary_key"`
-	AnalysisID uint    `json:"analysis_id" gorm:"index"`
-	Text       string  `json:"text" gorm:"not null"`
-	Type       string  `json:"type" gorm:"not null"` // person, organization, location, other
-	Confidence float64 `json:"confidence" gorm:"not null"`
-	StartPos   int     `json:"start_pos,omitempty"`
-	EndPos     int     `json:"end_pos,omitempty"`
+	ID         uint      `json:"id" gorm:"primary_key"`
+	AnalysisID uint      `json:"analysis_id" gorm:"index"`
+	Text       string    `json:"text" gorm:"not null"`
+	Type       string    `json:"type" gorm:"not null"` // person, organization, location, money, date, url, email, other
+	Confidence float64   `json:"confidence" gorm:"not null"`
+	StartPos   int       `json:"start_pos,omitempty"`
+	EndPos     int       `json:"end_pos,omitempty"`
 	CreatedAt  time.Time `json:"created_at"`
 }
 
@@ -62,6 +67,11 @@ type AnalyzeTextRequest struct {
 	Language string            `json:"language"`
 	Metadata map[string]string `json:"metadata"`
 	IsPublic bool              `json:"is_public"`
+	// KeywordMode selects "rake" (default) or "tfidf" keyword extraction.
+	KeywordMode string `json:"keyword_mode"`
+	// Translate forces translation/dictionary enrichment even if Language
+	// already matches Config.Translation.TargetLanguage.
+	Translate bool `json:"translate"`
 }
 
 // BatchAnalyzeTextRequest represents request to analyze multiple texts
@@ -71,18 +81,19 @@ type BatchAnalyzeTextRequest struct {
 
 // TextAnalysisResponse represents the response format for text analysis
 type TextAnalysisResponse struct {
-	ID          uint                    `json:"id"`
-	Text        string                  `json:"text"`
-	Language    string                  `json:"language"`
-	Sentiment   SentimentAnalysis       `json:"sentiment"`
-	Keywords    []KeywordResponse       `json:"keywords,omitempty"`
-	Entities    []EntityResponse        `json:"entities,omitempty"`
-	Readability ReadabilityAnalysis     `json:"readability"`
-	Statistics  TextStatistics          `json:"statistics"`
-	Metadata    map[string]string       `json:"metadata"`
-	IsPublic    bool                    `json:"is_public"`
-	CreatedAt   time.Time               `json:"created_at"`
-	UpdatedAt   time.Time               `json:"updated_at"`
+	ID          uint                `json:"id"`
+	Text        string              `json:"text"`
+	Language    string              `json:"language"`
+	Sentiment   SentimentAnalysis   `json:"sentiment"`
+	Keywords    []KeywordResponse   `json:"keywords,omitempty"`
+	Entities    []EntityResponse    `json:"entities,omitempty"`
+	Readability ReadabilityAnalysis `json:"readability"`
+	Statistics  TextStatistics      `json:"statistics"`
+	Metadata    map[string]string   `json:"metadata"`
+	IsPublic    bool                `json:"is_public"`
+	FilteredBy  []string            `json:"filtered_by,omitempty"`
+	CreatedAt   time.Time           `json:"created_at"`
+	UpdatedAt   time.Time           `json:"updated_at"`
 }
 
 // KeywordResponse represents the response format for keywords
@@ -105,11 +116,21 @@ type EntityResponse struct {
 
 // TextStatistics represents additional text statistics
 type TextStatistics struct {
-	WordCount              int     `json:"word_count"`
-	CharacterCount         int     `json:"character_count"`
-	SentenceCount          int     `json:"sentence_count"`
+	WordCount               int     `json:"word_count"`
+	CharacterCount          int     `json:"character_count"`
+	SentenceCount           int     `json:"sentence_count"`
 	AverageWordsPerSentence float64 `json:"average_words_per_sentence"`
-	KeywordDensity         float64 `json:"keyword_density"`
+	KeywordDensity          float64 `json:"keyword_density"`
+
+	// Readability formulas, all computed in one pass by textanalysis.Metrics.
+	// Zero when Language isn't English (see ReadabilitySupported).
+	FleschReadingEase    float64 `json:"flesch_reading_ease"`
+	FleschKincaidGrade   float64 `json:"flesch_kincaid_grade"`
+	GunningFog           float64 `json:"gunning_fog"`
+	SMOG                 float64 `json:"smog"`
+	ARI                  float64 `json:"automated_readability_index"`
+	ColemanLiau          float64 `json:"coleman_liau_index"`
+	ReadabilitySupported bool    `json:"readability_supported"`
 }
 
 // ToResponse converts a TextAnalysis to TextAnalysisResponse
@@ -122,6 +143,7 @@ func (ta *TextAnalysis) ToResponse() TextAnalysisResponse {
 		Readability: ta.Readability,
 		Metadata:    ta.Metadata,
 		IsPublic:    ta.IsPublic,
+		FilteredBy:  ta.FilteredBy,
 		CreatedAt:   ta.CreatedAt,
 		UpdatedAt:   ta.UpdatedAt,
 	}
@@ -156,26 +178,29 @@ func (ta *TextAnalysis) ToResponse() TextAnalysisResponse {
 	return response
 }
 
-// CalculateStatistics calculates text statistics
+// CalculateStatistics calculates text statistics, including the classical
+// readability formulas computed by textanalysis.Metrics.
 func (ta *TextAnalysis) CalculateStatistics() TextStatistics {
-	// This would integrate with your existing textUtils.ts logic
-	// For now, we'll provide a basic implementation
-	words := len(ta.Text) // Simplified - would use proper word counting
-	chars := len(ta.Text)
-	sentences := 1 // Simplified - would use proper sentence counting
-	
-	avgWordsPerSentence := float64(words) / float64(sentences)
+	m := textanalysis.Metrics(ta.Text, ta.Language)
+
 	keywordDensity := 0.0
-	if len(ta.Keywords) > 0 && words > 0 {
-		keywordDensity = float64(ta.Keywords[0].Frequency) / float64(words) * 100
+	if len(ta.Keywords) > 0 && m.WordCount > 0 {
+		keywordDensity = float64(ta.Keywords[0].Frequency) / float64(m.WordCount) * 100
 	}
 
 	return TextStatistics{
-		WordCount:               words,
-		CharacterCount:          chars,
-		SentenceCount:           sentences,
-		AverageWordsPerSentence: avgWordsPerSentence,
+		WordCount:               m.WordCount,
+		CharacterCount:          m.CharacterCount,
+		SentenceCount:           m.SentenceCount,
+		AverageWordsPerSentence: m.AvgWordsPerSentence,
 		KeywordDensity:          keywordDensity,
+		FleschReadingEase:       m.FleschReadingEase,
+		FleschKincaidGrade:      m.FleschKincaidGrade,
+		GunningFog:              m.GunningFog,
+		SMOG:                    m.SMOG,
+		ARI:                     m.ARI,
+		ColemanLiau:             m.ColemanLiau,
+		ReadabilitySupported:    m.Supported,
 	}
 }
 
@@ -188,4 +213,13 @@ func (ta *TextAnalysis) BeforeCreate(scope *gorm.Scope) error {
 		ta.Language = "en"
 	}
 	return nil
-}
\ No newline at end of file
+}
+
+// AfterCreate and AfterDelete used to record audit events here, but they're
+// declared against jinzhu/gorm's v1 callback signature (scope *gorm.Scope)
+// while internal/storage runs gorm.io/gorm v2, which resolves hooks via its
+// own-package AfterCreate/AfterDelete(tx *gorm.DB) error interfaces — a
+// v1-shaped method never satisfies that, so neither ever ran. Creation is
+// audited from TextHandler.AnalyzeText/BatchAnalyzeText, which already call
+// audit.Record with the real analysis; deletion is audited the same way
+// from TextHandler.DeleteAnalysis.
@@ -0,0 +1,29 @@
+package models
+
+import "time"
+
+// Translation is a translated rendering of a TextAnalysis into a target
+// language, produced by the configured translation.Provider when the
+// analysis's own language doesn't match Config.Translation.TargetLanguage
+// (or the caller set AnalyzeTextRequest.Translate). Senses holds whatever
+// per-token phonetics and dictionary-style explanations the provider
+// returned, which for most machine-translation backends is empty.
+type Translation struct {
+	ID             uint      `json:"id" gorm:"primary_key"`
+	AnalysisID     uint      `json:"analysis_id" gorm:"index"`
+	SourceLang     string    `json:"source_lang" gorm:"not null"`
+	TargetLang     string    `json:"target_lang" gorm:"not null;index"`
+	TranslatedText string    `json:"translated_text" gorm:"type:text;not null"`
+	Senses         []Sense   `json:"senses,omitempty" gorm:"type:jsonb"`
+	CreatedAt      time.Time `json:"created_at"`
+}
+
+// Sense is one dictionary-style explanation of a translated word or
+// phrase: its part of speech, phonetic spelling, a definition, and example
+// sentences using it.
+type Sense struct {
+	PartOfSpeech string   `json:"part_of_speech,omitempty"`
+	Phonetic     string   `json:"phonetic,omitempty"`
+	Definition   string   `json:"definition,omitempty"`
+	Examples     []string `json:"examples,omitempty"`
+}
@@ -0,0 +1,37 @@
+package nlp
+
+import (
+	"log"
+	"time"
+
+	"github.com/bartosz/stocks-out-for-harambe/backend/internal/config"
+)
+
+// BuildProvider selects the Provider configured by cfg.Provider: Builtin by
+// default, HTTPProvider for "http", or GRPCProvider for "grpc". Falls back
+// to Builtin (logging a warning) if the configured remote provider is
+// missing required config or can't be constructed, so a misconfiguration
+// doesn't take text analysis down entirely.
+func BuildProvider(cfg config.NLPConfig) Provider {
+	switch cfg.Provider {
+	case "http":
+		if cfg.HTTPBaseURL == "" {
+			log.Printf("Warning: NLP_PROVIDER=http but NLP_HTTP_BASE_URL is unset, falling back to builtin")
+			return NewBuiltin()
+		}
+		return NewHTTPProvider(cfg.HTTPBaseURL, cfg.HTTPAPIKey, time.Duration(cfg.HTTPTimeoutSeconds)*time.Second, cfg.HTTPMaxRetries)
+	case "grpc":
+		if cfg.GRPCAddress == "" {
+			log.Printf("Warning: NLP_PROVIDER=grpc but NLP_GRPC_ADDRESS is unset, falling back to builtin")
+			return NewBuiltin()
+		}
+		provider, err := NewGRPCProvider(cfg.GRPCAddress)
+		if err != nil {
+			log.Printf("Warning: failed to initialize grpc nlp provider, falling back to builtin: %v", err)
+			return NewBuiltin()
+		}
+		return provider
+	default:
+		return NewBuiltin()
+	}
+}
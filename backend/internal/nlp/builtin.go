@@ -0,0 +1,124 @@
+package nlp
+
+import (
+	"context"
+	"math"
+	"regexp"
+	"strings"
+
+	"github.com/bartosz/stocks-out-for-harambe/backend/internal/textanalysis"
+	"github.com/bartosz/stocks-out-for-harambe/backend/internal/textanalysis/extract"
+)
+
+// Builtin is the in-process lexicon/regex-based Provider TextHandler used
+// inline before Provider existed. Its sentiment word lists and entity rules
+// (extract.Entities: a bundled list of countries and major cities, and a
+// run of capitalized words for person) are English-only, so it's a poor
+// fit for non-English input —
+// HTTPProvider or GRPCProvider are the intended replacement once a real NLP
+// backend is configured.
+type Builtin struct{}
+
+// NewBuiltin creates a Builtin provider.
+func NewBuiltin() *Builtin {
+	return &Builtin{}
+}
+
+var sentimentWordPattern = regexp.MustCompile(`\b\w+\b`)
+
+var positiveWords = []string{
+	"good", "great", "excellent", "amazing", "wonderful", "fantastic", "awesome",
+	"love", "like", "enjoy", "happy", "pleased", "satisfied", "perfect",
+	"brilliant", "outstanding", "superb", "magnificent", "impressive",
+	"positive", "success", "successful", "achievement", "accomplish",
+	"effective", "efficient", "valuable", "helpful", "useful",
+}
+
+var negativeWords = []string{
+	"bad", "terrible", "awful", "horrible", "disgusting", "hate", "dislike",
+	"angry", "frustrated", "disappointed", "upset", "sad", "depressed",
+	"poor", "weak", "fail", "failure", "problem", "issue", "difficult",
+	"impossible", "useless", "worthless", "waste", "expensive",
+	"slow", "broken", "error", "bug", "wrong", "incorrect",
+}
+
+// Sentiment scores text by counting lexicon hits.
+func (b *Builtin) Sentiment(ctx context.Context, text, language string) (SentimentResult, error) {
+	words := sentimentWordPattern.FindAllString(strings.ToLower(text), -1)
+
+	positiveCount := 0
+	negativeCount := 0
+	for _, word := range words {
+		for _, pos := range positiveWords {
+			if word == pos {
+				positiveCount++
+				break
+			}
+		}
+		for _, neg := range negativeWords {
+			if word == neg {
+				negativeCount++
+				break
+			}
+		}
+	}
+
+	totalSentimentWords := positiveCount + negativeCount
+	if totalSentimentWords == 0 {
+		return SentimentResult{Score: 0, Label: "neutral", Confidence: 0.5}, nil
+	}
+
+	score := float64(positiveCount-negativeCount) / float64(totalSentimentWords)
+	confidence := math.Min(0.9, math.Max(0.1, float64(totalSentimentWords)/float64(len(words))*5))
+
+	label := "neutral"
+	if score > 0.1 {
+		label = "positive"
+	} else if score < -0.1 {
+		label = "negative"
+	}
+
+	return SentimentResult{
+		Score:      math.Round(score*1000) / 1000,
+		Label:      label,
+		Confidence: math.Round(confidence*1000) / 1000,
+	}, nil
+}
+
+// Keywords extracts keywords via the RAKE implementation in
+// textanalysis/extract. keywordMode is unused here since Builtin's TF-IDF
+// path stays in TextHandler (see Provider's doc comment).
+func (b *Builtin) Keywords(ctx context.Context, text, language, keywordMode string) ([]KeywordResult, error) {
+	keywords := extract.Keywords(text, extract.KeywordOptions{})
+	results := make([]KeywordResult, len(keywords))
+	for i, k := range keywords {
+		results[i] = KeywordResult{Word: k.Word, Frequency: k.Frequency, Relevance: k.Relevance}
+	}
+	return results, nil
+}
+
+// Entities extracts named entities via the rule-based extract.Entities
+// cascade (gazetteer, regexes, capitalization heuristic).
+func (b *Builtin) Entities(ctx context.Context, text, language string) ([]EntityResult, error) {
+	entities := extract.Entities(text)
+	results := make([]EntityResult, len(entities))
+	for i, e := range entities {
+		results[i] = EntityResult{Text: e.Text, Type: e.Type, Confidence: e.Confidence, StartPos: e.StartPos, EndPos: e.EndPos}
+	}
+	return results, nil
+}
+
+// Readability computes the Flesch Reading Ease score and level via
+// textanalysis.Metrics.
+func (b *Builtin) Readability(ctx context.Context, text, language string) (ReadabilityResult, error) {
+	m := textanalysis.Metrics(text, language)
+	if !m.Supported || m.WordCount == 0 || m.SentenceCount == 0 {
+		return ReadabilityResult{Score: 0, Level: "Unreadable"}, nil
+	}
+
+	finalScore := math.Max(0, math.Min(100, m.FleschReadingEase))
+	return ReadabilityResult{
+		Score: math.Round(finalScore*10) / 10,
+		Level: m.Level,
+	}, nil
+}
@@ -0,0 +1,78 @@
+package nlp
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/encoding"
+)
+
+func init() {
+	encoding.RegisterCodec(jsonCodec{})
+}
+
+// jsonCodec lets GRPCProvider invoke a remote NLP model server's RPCs using
+// JSON instead of protobuf wire encoding, so a spaCy/Stanza-style model
+// server doesn't need a protoc-generated Go client checked into this repo —
+// the server side just needs to speak the same JSON shapes on these method
+// names.
+type jsonCodec struct{}
+
+func (jsonCodec) Name() string { return "json" }
+
+func (jsonCodec) Marshal(v interface{}) ([]byte, error) {
+	return json.Marshal(v)
+}
+
+func (jsonCodec) Unmarshal(data []byte, v interface{}) error {
+	return json.Unmarshal(data, v)
+}
+
+// GRPCProvider calls a remote spaCy/Stanza-style NLP model server over
+// gRPC.
+type GRPCProvider struct {
+	conn *grpc.ClientConn
+}
+
+// NewGRPCProvider dials addr (e.g. "nlp-service:50051").
+func NewGRPCProvider(addr string) (*GRPCProvider, error) {
+	conn, err := grpc.Dial(addr,
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithDefaultCallOptions(grpc.CallContentSubtype("json")),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("nlp: failed to dial grpc nlp service at %s: %w", addr, err)
+	}
+	return &GRPCProvider{conn: conn}, nil
+}
+
+// Sentiment invokes /nlp.NLPService/Sentiment.
+func (p *GRPCProvider) Sentiment(ctx context.Context, text, language string) (SentimentResult, error) {
+	var result SentimentResult
+	err := p.conn.Invoke(ctx, "/nlp.NLPService/Sentiment", sentimentRequest{Text: text, Language: language}, &result)
+	return result, err
+}
+
+// Keywords invokes /nlp.NLPService/Keywords.
+func (p *GRPCProvider) Keywords(ctx context.Context, text, language, keywordMode string) ([]KeywordResult, error) {
+	var result []KeywordResult
+	err := p.conn.Invoke(ctx, "/nlp.NLPService/Keywords", keywordsRequest{Text: text, Language: language, KeywordMode: keywordMode}, &result)
+	return result, err
+}
+
+// Entities invokes /nlp.NLPService/Entities.
+func (p *GRPCProvider) Entities(ctx context.Context, text, language string) ([]EntityResult, error) {
+	var result []EntityResult
+	err := p.conn.Invoke(ctx, "/nlp.NLPService/Entities", textLanguageRequest{Text: text, Language: language}, &result)
+	return result, err
+}
+
+// Readability invokes /nlp.NLPService/Readability.
+func (p *GRPCProvider) Readability(ctx context.Context, text, language string) (ReadabilityResult, error) {
+	var result ReadabilityResult
+	err := p.conn.Invoke(ctx, "/nlp.NLPService/Readability", textLanguageRequest{Text: text, Language: language}, &result)
+	return result, err
+}
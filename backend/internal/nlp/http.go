@@ -0,0 +1,143 @@
+package nlp
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+// HTTPProvider calls a remote NLP microservice over JSON-over-HTTP, the
+// same Bearer-auth/retry-with-backoff shape as FirecrawlClient.makeRequest.
+type HTTPProvider struct {
+	baseURL    string
+	apiKey     string
+	maxRetries int
+	httpClient *http.Client
+}
+
+// NewHTTPProvider creates an HTTPProvider talking to baseURL.
+func NewHTTPProvider(baseURL, apiKey string, timeout time.Duration, maxRetries int) *HTTPProvider {
+	return &HTTPProvider{
+		baseURL:    baseURL,
+		apiKey:     apiKey,
+		maxRetries: maxRetries,
+		httpClient: &http.Client{Timeout: timeout},
+	}
+}
+
+type sentimentRequest struct {
+	Text     string `json:"text"`
+	Language string `json:"language"`
+}
+
+// Sentiment calls POST {baseURL}/v1/sentiment.
+func (p *HTTPProvider) Sentiment(ctx context.Context, text, language string) (SentimentResult, error) {
+	var result SentimentResult
+	err := p.makeRequest(ctx, "/v1/sentiment", sentimentRequest{Text: text, Language: language}, &result)
+	return result, err
+}
+
+type keywordsRequest struct {
+	Text        string `json:"text"`
+	Language    string `json:"language"`
+	KeywordMode string `json:"keyword_mode"`
+}
+
+// Keywords calls POST {baseURL}/v1/keywords.
+func (p *HTTPProvider) Keywords(ctx context.Context, text, language, keywordMode string) ([]KeywordResult, error) {
+	var result []KeywordResult
+	err := p.makeRequest(ctx, "/v1/keywords", keywordsRequest{Text: text, Language: language, KeywordMode: keywordMode}, &result)
+	return result, err
+}
+
+type textLanguageRequest struct {
+	Text     string `json:"text"`
+	Language string `json:"language"`
+}
+
+// Entities calls POST {baseURL}/v1/entities.
+func (p *HTTPProvider) Entities(ctx context.Context, text, language string) ([]EntityResult, error) {
+	var result []EntityResult
+	err := p.makeRequest(ctx, "/v1/entities", textLanguageRequest{Text: text, Language: language}, &result)
+	return result, err
+}
+
+// Readability calls POST {baseURL}/v1/readability.
+func (p *HTTPProvider) Readability(ctx context.Context, text, language string) (ReadabilityResult, error) {
+	var result ReadabilityResult
+	err := p.makeRequest(ctx, "/v1/readability", textLanguageRequest{Text: text, Language: language}, &result)
+	return result, err
+}
+
+// makeRequest mirrors FirecrawlClient.makeRequest: exponential backoff on
+// 429/5xx, Bearer auth, no retry on other client errors.
+func (p *HTTPProvider) makeRequest(ctx context.Context, endpoint string, reqBody, respBody interface{}) error {
+	url := p.baseURL + endpoint
+
+	jsonData, err := json.Marshal(reqBody)
+	if err != nil {
+		return fmt.Errorf("nlp: failed to marshal request body: %w", err)
+	}
+
+	var lastErr error
+	for attempt := 0; attempt <= p.maxRetries; attempt++ {
+		if attempt > 0 {
+			// Exponential backoff: 1s, 2s, 4s, 8s...
+			waitTime := time.Duration(1<<uint(attempt-1)) * time.Second
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-time.After(waitTime):
+			}
+		}
+
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewBuffer(jsonData))
+		if err != nil {
+			return fmt.Errorf("nlp: failed to create request: %w", err)
+		}
+		req.Header.Set("Content-Type", "application/json")
+		if p.apiKey != "" {
+			req.Header.Set("Authorization", "Bearer "+p.apiKey)
+		}
+
+		resp, err := p.httpClient.Do(req)
+		if err != nil {
+			lastErr = fmt.Errorf("nlp: request failed: %w", err)
+			continue
+		}
+
+		responseBody, err := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		if err != nil {
+			lastErr = fmt.Errorf("nlp: failed to read response body: %w", err)
+			continue
+		}
+
+		if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+			if respBody != nil {
+				if err := json.Unmarshal(responseBody, respBody); err != nil {
+					return fmt.Errorf("nlp: failed to unmarshal response: %w", err)
+				}
+			}
+			return nil
+		}
+
+		switch {
+		case resp.StatusCode == http.StatusTooManyRequests:
+			lastErr = fmt.Errorf("nlp: rate limited (429): %s", string(responseBody))
+			// Continue retrying for rate limits
+		case resp.StatusCode >= 500:
+			lastErr = fmt.Errorf("nlp: server error (%d): %s", resp.StatusCode, string(responseBody))
+			// Continue retrying for server errors
+		default:
+			// Don't retry for other client errors
+			return fmt.Errorf("nlp: client error (%d): %s", resp.StatusCode, string(responseBody))
+		}
+	}
+
+	return fmt.Errorf("nlp: request to %s failed after %d retries: %w", endpoint, p.maxRetries, lastErr)
+}
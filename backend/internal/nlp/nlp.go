@@ -0,0 +1,50 @@
+// Package nlp provides pluggable sentiment/keyword/entity/readability
+// analysis behind a Provider interface, so TextHandler doesn't need to know
+// whether it's talking to the in-process lexicon, a remote HTTP
+// microservice, or a gRPC model server. Result types are defined here
+// rather than reusing models.* so this package doesn't need to import
+// models, matching the separation audit.Event keeps from models.
+package nlp
+
+import "context"
+
+// SentimentResult is Provider's sentiment analysis output.
+type SentimentResult struct {
+	Score      float64
+	Label      string
+	Confidence float64
+}
+
+// KeywordResult is one extracted keyword and its score.
+type KeywordResult struct {
+	Word      string
+	Frequency int
+	Relevance float64
+}
+
+// EntityResult is one extracted named entity.
+type EntityResult struct {
+	Text       string
+	Type       string
+	Confidence float64
+	StartPos   int
+	EndPos     int
+}
+
+// ReadabilityResult is Provider's readability scoring output.
+type ReadabilityResult struct {
+	Score float64
+	Level string
+}
+
+// Provider performs the individual analysis steps TextHandler composes into
+// a TextAnalysis. keywordMode is passed through so callers can still ask
+// for "tfidf" vs. a provider's default extraction; TextHandler's TF-IDF path
+// stays handler-local rather than going through Provider, since it depends
+// on a corpus-wide document-frequency table in Postgres no Provider owns.
+type Provider interface {
+	Sentiment(ctx context.Context, text, language string) (SentimentResult, error)
+	Keywords(ctx context.Context, text, language, keywordMode string) ([]KeywordResult, error)
+	Entities(ctx context.Context, text, language string) ([]EntityResult, error)
+	Readability(ctx context.Context, text, language string) (ReadabilityResult, error)
+}
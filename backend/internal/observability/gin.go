@@ -0,0 +1,29 @@
+package observability
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/bartosz/stocks-out-for-harambe/backend/internal/metrics"
+	"github.com/gin-gonic/gin"
+	"go.opentelemetry.io/contrib/instrumentation/github.com/gin-gonic/gin/otelgin"
+)
+
+// GinMiddleware adds an HTTP server span per request (via otelgin, tagged
+// with the route template) and records symbiot_http_requests_total /
+// symbiot_http_request_duration_seconds for it. Install it alongside
+// middleware.SecurityHeaders/CORS in router.go.
+func GinMiddleware(serviceName string) gin.HandlerFunc {
+	traced := otelgin.Middleware(serviceName)
+
+	return func(c *gin.Context) {
+		start := time.Now()
+		traced(c)
+
+		route := c.FullPath()
+		if route == "" {
+			route = "unmatched"
+		}
+		metrics.ObserveHTTPRequest(c.Request.Method, route, strconv.Itoa(c.Writer.Status()), time.Since(start).Seconds())
+	}
+}
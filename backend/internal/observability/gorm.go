@@ -0,0 +1,125 @@
+package observability
+
+import (
+	"time"
+
+	"github.com/bartosz/stocks-out-for-harambe/backend/internal/metrics"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+	"gorm.io/gorm"
+)
+
+// spanKey/startKey are the gorm.Statement.Settings keys the before-hooks
+// stash the span and start time under, so the matching after-hook can end
+// the span and measure elapsed time against slowThreshold.
+const (
+	spanKey  = "observability:span"
+	startKey = "observability:span_start"
+)
+
+// tracingPlugin is a hand-rolled gorm.Plugin rather than
+// gorm.io/plugin/opentelemetry/tracing: that module requires Go 1.22+,
+// newer than this backend currently targets, and the repo's existing
+// extension points (audit.Sink, search.Index, nlp.Provider) are all
+// hand-rolled anyway.
+type tracingPlugin struct {
+	tracer        trace.Tracer
+	slowThreshold time.Duration
+}
+
+// NewGormPlugin returns a gorm.Plugin that starts a span around every
+// Create/Query/Update/Delete/Row/Raw call, tagged with the SQL statement
+// and rows affected, and increments metrics.DBSlowQueriesTotal for calls
+// at or above slowThreshold — the same threshold storage.NewDatabase
+// configures its GORM logger with.
+func NewGormPlugin(slowThreshold time.Duration) gorm.Plugin {
+	return &tracingPlugin{
+		tracer:        otel.Tracer("symbiot/gorm"),
+		slowThreshold: slowThreshold,
+	}
+}
+
+func (p *tracingPlugin) Name() string {
+	return "observability:tracing"
+}
+
+func (p *tracingPlugin) Initialize(db *gorm.DB) error {
+	if err := db.Callback().Create().Before("gorm:create").Register("observability:before_create", p.before); err != nil {
+		return err
+	}
+	if err := db.Callback().Create().After("gorm:create").Register("observability:after_create", p.after); err != nil {
+		return err
+	}
+	if err := db.Callback().Query().Before("gorm:query").Register("observability:before_query", p.before); err != nil {
+		return err
+	}
+	if err := db.Callback().Query().After("gorm:query").Register("observability:after_query", p.after); err != nil {
+		return err
+	}
+	if err := db.Callback().Update().Before("gorm:update").Register("observability:before_update", p.before); err != nil {
+		return err
+	}
+	if err := db.Callback().Update().After("gorm:update").Register("observability:after_update", p.after); err != nil {
+		return err
+	}
+	if err := db.Callback().Delete().Before("gorm:delete").Register("observability:before_delete", p.before); err != nil {
+		return err
+	}
+	if err := db.Callback().Delete().After("gorm:delete").Register("observability:after_delete", p.after); err != nil {
+		return err
+	}
+	if err := db.Callback().Row().Before("gorm:row").Register("observability:before_row", p.before); err != nil {
+		return err
+	}
+	if err := db.Callback().Row().After("gorm:row").Register("observability:after_row", p.after); err != nil {
+		return err
+	}
+	if err := db.Callback().Raw().Before("gorm:raw").Register("observability:before_raw", p.before); err != nil {
+		return err
+	}
+	if err := db.Callback().Raw().After("gorm:raw").Register("observability:after_raw", p.after); err != nil {
+		return err
+	}
+	return nil
+}
+
+// before starts a span for the call about to run, named after its target
+// table, and stashes it (plus a start time for the slow-query check in
+// after) on the statement for after to pick back up.
+func (p *tracingPlugin) before(db *gorm.DB) {
+	ctx, span := p.tracer.Start(db.Statement.Context, "gorm."+db.Statement.Table)
+	db.Statement.Context = ctx
+	db.Statement.Settings.Store(spanKey, span)
+	db.Statement.Settings.Store(startKey, time.Now())
+}
+
+// after ends the span before started, tagging it with the final SQL,
+// rows affected, and error status, and records a slow-query sample if the
+// call ran at or above slowThreshold.
+func (p *tracingPlugin) after(db *gorm.DB) {
+	value, ok := db.Statement.Settings.Load(spanKey)
+	if !ok {
+		return
+	}
+	span, ok := value.(trace.Span)
+	if !ok {
+		return
+	}
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("db.statement", db.Statement.SQL.String()),
+		attribute.Int64("db.rows_affected", db.Statement.RowsAffected),
+	)
+	if db.Error != nil {
+		span.SetStatus(codes.Error, db.Error.Error())
+	}
+
+	if started, ok := db.Statement.Settings.Load(startKey); ok {
+		if startTime, ok := started.(time.Time); ok && time.Since(startTime) >= p.slowThreshold {
+			metrics.ObserveSlowQuery(db.Statement.Table)
+		}
+	}
+}
@@ -0,0 +1,36 @@
+package observability
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/bartosz/stocks-out-for-harambe/backend/internal/metrics"
+)
+
+// WatchPoolStats periodically scrapes sqlDB.Stats() into the
+// symbiot_db_pool_* gauges/counter at interval (falling back to 15s if
+// interval is zero or negative). Returns a stop func the caller should
+// invoke on shutdown, mirroring middleware.WatchCORSConfig's
+// watcher-plus-stop-func shape.
+func WatchPoolStats(sqlDB *sql.DB, interval time.Duration) (stop func()) {
+	if interval <= 0 {
+		interval = 15 * time.Second
+	}
+
+	ticker := time.NewTicker(interval)
+	done := make(chan struct{})
+
+	go func() {
+		for {
+			select {
+			case <-ticker.C:
+				metrics.SetPoolStats(sqlDB.Stats())
+			case <-done:
+				ticker.Stop()
+				return
+			}
+		}
+	}()
+
+	return func() { close(done) }
+}
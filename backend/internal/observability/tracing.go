@@ -0,0 +1,58 @@
+// Package observability wires OpenTelemetry tracing and Prometheus
+// instrumentation across the HTTP and database layers: GinMiddleware adds
+// HTTP server spans and request metrics, NewGormPlugin adds a span per SQL
+// query plus a slow-query counter, and WatchPoolStats periodically scrapes
+// the connection pool into gauges. All three are additive — with
+// ObservabilityConfig.OTLPEndpoint unset, InitTracer leaves OTel's default
+// global no-op tracer provider in place, so spans are never recorded or
+// exported and the Prometheus side keeps working standalone.
+package observability
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/bartosz/stocks-out-for-harambe/backend/internal/config"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.24.0"
+)
+
+// InitTracer configures the global OTel tracer provider from cfg and
+// returns a shutdown func the caller should defer. If cfg.OTLPEndpoint is
+// empty, it installs nothing, leaving OTel's default global no-op provider
+// in place (GinMiddleware/NewGormPlugin still start spans, non-recording
+// ones, so they don't need to branch on whether tracing is enabled), and
+// returns a no-op shutdown.
+func InitTracer(ctx context.Context, cfg config.ObservabilityConfig) (func(context.Context) error, error) {
+	if cfg.OTLPEndpoint == "" {
+		return func(context.Context) error { return nil }, nil
+	}
+
+	exporter, err := otlptracegrpc.New(ctx,
+		otlptracegrpc.WithEndpoint(cfg.OTLPEndpoint),
+		otlptracegrpc.WithInsecure(),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create OTLP trace exporter: %w", err)
+	}
+
+	res, err := resource.New(ctx, resource.WithAttributes(
+		semconv.ServiceName(cfg.ServiceName),
+	))
+	if err != nil {
+		return nil, fmt.Errorf("failed to build OTel resource: %w", err)
+	}
+
+	tp := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+	)
+	otel.SetTracerProvider(tp)
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+
+	return tp.Shutdown, nil
+}
@@ -0,0 +1,156 @@
+// Package progress provides a small per-topic pub/sub broker for streaming
+// incremental updates to SSE clients, with a bounded replay buffer so a
+// client that reconnects with a Last-Event-ID doesn't miss ticks it was
+// disconnected for. It backs the batch text-analysis job progress stream;
+// services.ProgressHub plays the equivalent role for extraction sessions
+// (it predates this package and wasn't migrated, since its poll-and-publish
+// call sites don't need replay or monotonic IDs).
+package progress
+
+import "sync"
+
+// replayBufferSize is how many past events per topic are retained for
+// reconnecting clients that send a Last-Event-ID.
+const replayBufferSize = 50
+
+// subscriberBuffer is the per-subscriber channel depth for live events
+// (Subscribe adds room for any replayed events on top of it). Unlike
+// services.ProgressHub (which drops the new event on a full buffer), Broker
+// drops the oldest queued event instead, so a slow client still converges on
+// the latest state rather than getting stuck behind stale ticks.
+const subscriberBuffer = 16
+
+// Event is a single message broadcast to subscribers of a topic. ID is
+// monotonically increasing per topic and is what Subscribe's lastEventID
+// parameter compares against to decide which buffered events to replay.
+type Event struct {
+	ID   uint64      `json:"id"`
+	Type string      `json:"type"`
+	Data interface{} `json:"data"`
+}
+
+// topic is one Broker-managed feed: its own sequence counter, replay
+// buffer, and subscriber set, all guarded by the same mutex so Publish and
+// Subscribe never observe each other mid-update.
+type topic struct {
+	mu     sync.Mutex
+	nextID uint64
+	replay []Event
+	subs   map[chan Event]struct{}
+}
+
+// Broker is a broadcast hub that fans events out to every subscriber of a
+// topic (typically a job ID), keeping a bounded replay buffer per topic so
+// reconnecting clients can resume from their Last-Event-ID instead of
+// missing whatever happened while they were disconnected.
+type Broker struct {
+	mu     sync.RWMutex
+	topics map[string]*topic
+}
+
+// NewBroker creates an empty Broker.
+func NewBroker() *Broker {
+	return &Broker{topics: make(map[string]*topic)}
+}
+
+func (b *Broker) topicFor(name string) *topic {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	t, ok := b.topics[name]
+	if !ok {
+		t = &topic{subs: make(map[chan Event]struct{})}
+		b.topics[name] = t
+	}
+	return t
+}
+
+// Publish broadcasts an event to every current subscriber of topicName,
+// assigning it the topic's next sequence ID and appending it to the topic's
+// replay buffer. Subscribers whose buffer is full have their oldest queued
+// event dropped to make room, rather than dropping the new one, so a slow
+// client still converges on the latest state.
+func (b *Broker) Publish(topicName, eventType string, data interface{}) Event {
+	t := b.topicFor(topicName)
+
+	t.mu.Lock()
+	t.nextID++
+	event := Event{ID: t.nextID, Type: eventType, Data: data}
+	t.replay = append(t.replay, event)
+	if len(t.replay) > replayBufferSize {
+		t.replay = t.replay[len(t.replay)-replayBufferSize:]
+	}
+	subs := make([]chan Event, 0, len(t.subs))
+	for ch := range t.subs {
+		subs = append(subs, ch)
+	}
+	t.mu.Unlock()
+
+	for _, ch := range subs {
+		select {
+		case ch <- event:
+		default:
+			// Buffer is full: drop the oldest queued event to make room, then
+			// retry once. If another goroutine drains the channel between the
+			// two selects the retry just queues normally.
+			select {
+			case <-ch:
+			default:
+			}
+			select {
+			case ch <- event:
+			default:
+			}
+		}
+	}
+
+	return event
+}
+
+// Subscribe registers a new listener for topicName's events, replaying
+// whatever buffered events have an ID greater than lastEventID before
+// returning, so a client reconnecting with a Last-Event-ID header doesn't
+// miss ticks published while it was disconnected. Pass lastEventID 0 for a
+// fresh subscription with no replay. The returned func must be called to
+// unregister the listener and release its channel.
+func (b *Broker) Subscribe(topicName string, lastEventID uint64) (<-chan Event, func()) {
+	t := b.topicFor(topicName)
+
+	t.mu.Lock()
+	var missed []Event
+	for _, event := range t.replay {
+		if event.ID > lastEventID {
+			missed = append(missed, event)
+		}
+	}
+	// Size the channel so every replayed event fits on top of the usual
+	// subscriberBuffer headroom for live events.
+	ch := make(chan Event, subscriberBuffer+len(missed))
+	for _, event := range missed {
+		ch <- event
+	}
+	t.subs[ch] = struct{}{}
+	t.mu.Unlock()
+
+	unsubscribe := func() {
+		t.mu.Lock()
+		defer t.mu.Unlock()
+		delete(t.subs, ch)
+		close(ch)
+	}
+
+	return ch, unsubscribe
+}
+
+// HasSubscribers reports whether any client is currently listening to
+// topicName's stream.
+func (b *Broker) HasSubscribers(topicName string) bool {
+	b.mu.RLock()
+	t, ok := b.topics[topicName]
+	b.mu.RUnlock()
+	if !ok {
+		return false
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return len(t.subs) > 0
+}
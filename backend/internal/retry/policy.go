@@ -0,0 +1,71 @@
+package retry
+
+import (
+	"fmt"
+	"time"
+)
+
+// Policy is the JSON-serializable description of a retry strategy, stored in
+// an ExtractionSession's Metadata["retry_policy"] and chosen per session at
+// creation time via CreateExtractionSessionRequest.
+type Policy struct {
+	Strategy Name          `json:"strategy"`
+	Base     time.Duration `json:"base,omitempty"`
+	Max      time.Duration `json:"max,omitempty"`
+	Interval time.Duration `json:"interval,omitempty"`
+
+	// CircuitBreakerThreshold trips the session to ExtractionStatusFailed
+	// once this many of its URL extractions have failed with their retries
+	// exhausted. The count covers the whole session and is not reset by
+	// URLs that succeed in between. Zero disables the breaker.
+	CircuitBreakerThreshold int `json:"circuit_breaker_threshold,omitempty"`
+}
+
+// DefaultPolicy is used when a session's Metadata carries no retry_policy.
+func DefaultPolicy() Policy {
+	return Policy{
+		Strategy:                NameExponentialJitter,
+		Base:                    time.Second,
+		Max:                     60 * time.Second,
+		CircuitBreakerThreshold: 5,
+	}
+}
+
+// Build constructs the Strategy described by the policy, wrapped in
+// HTTPStatusAware so 4xx failures other than 408/429 short-circuit unless
+// the failure carries a Retry-After value.
+func (p Policy) Build() (Strategy, error) {
+	var inner Strategy
+
+	switch p.Strategy {
+	case "", NameExponentialJitter:
+		inner = ExponentialJitter{Base: p.Base, Max: p.Max}
+	case NameDecorrelatedJitter:
+		inner = &DecorrelatedJitter{Base: p.Base, Cap: p.Max}
+	case NameFixedInterval:
+		inner = FixedInterval{Interval: p.Interval}
+	case NameLinearBackoff:
+		inner = LinearBackoff{Base: p.Base, Increment: p.Interval}
+	default:
+		return nil, fmt.Errorf("retry: unknown strategy %q", p.Strategy)
+	}
+
+	return HTTPStatusAware{Inner: inner}, nil
+}
+
+// CircuitBreaker trips a session to a failed state once too many of its URL
+// extractions have exhausted their retries (counted over the whole session,
+// not as a consecutive run), protecting against runaway retry loops on a
+// permanently broken source.
+type CircuitBreaker struct {
+	Threshold int
+}
+
+// ShouldTrip reports whether consecutiveFailures has reached the breaker's
+// threshold. A zero or negative Threshold disables the breaker.
+func (cb CircuitBreaker) ShouldTrip(consecutiveFailures int) bool {
+	if cb.Threshold <= 0 {
+		return false
+	}
+	return consecutiveFailures >= cb.Threshold
+}
@@ -0,0 +1,163 @@
+// Package retry provides pluggable backoff strategies for extraction
+// retries, replacing the free-form RetryStrategy string previously stored on
+// models.ExtractionRetry with typed, independently testable policies.
+package retry
+
+import (
+	"math"
+	"math/rand"
+	"sync"
+	"time"
+
+	"github.com/bartosz/stocks-out-for-harambe/backend/internal/models"
+)
+
+// Strategy decides how long to wait before the next retry attempt, and
+// whether a retry should happen at all. Implementations must be safe for
+// concurrent use since a single Strategy instance is typically shared across
+// all URLExtractions in a session.
+type Strategy interface {
+	// NextDelay returns the delay to wait before retrying, and whether a
+	// retry should be attempted at all. attempt is 1-indexed (the attempt
+	// about to be made). lastErr is the most recent retry record, or nil if
+	// this is the first attempt.
+	NextDelay(attempt int, lastErr *models.ExtractionRetry) (time.Duration, bool)
+}
+
+// Name identifies a Strategy for storage in Metadata and for selection via
+// Policy.Build, which accepts every Name here except NameHTTPStatusAware
+// (Build wraps each strategy in HTTPStatusAware itself).
+type Name string
+
+const (
+	NameExponentialJitter  Name = "exponential_jitter"
+	NameDecorrelatedJitter Name = "decorrelated_jitter"
+	NameFixedInterval      Name = "fixed_interval"
+	NameLinearBackoff      Name = "linear_backoff"
+	NameHTTPStatusAware    Name = "http_status_aware"
+)
+
+// ExponentialJitter implements "full jitter" exponential backoff as
+// described in the AWS Architecture Blog: delay = random(0, min(max,
+// base*2^(attempt-1))), with attempt 1-indexed.
+type ExponentialJitter struct {
+	Base time.Duration
+	Max  time.Duration
+}
+
+// NextDelay implements Strategy.
+func (s ExponentialJitter) NextDelay(attempt int, _ *models.ExtractionRetry) (time.Duration, bool) {
+	base := s.Base
+	if base <= 0 {
+		base = time.Second
+	}
+	max := s.Max
+	if max <= 0 {
+		max = 60 * time.Second
+	}
+
+	upper := float64(base) * math.Pow(2, float64(attempt-1))
+	if upper > float64(max) {
+		upper = float64(max)
+	}
+	return time.Duration(rand.Float64() * upper), true
+}
+
+// DecorrelatedJitter implements the "decorrelated jitter" formula from the
+// same AWS post: sleep = min(cap, uniform(base, prev*3)). It carries state
+// between calls, so a single instance should be reused per session/URL
+// rather than reconstructed each attempt.
+type DecorrelatedJitter struct {
+	Base time.Duration
+	Cap  time.Duration
+
+	mu   sync.Mutex
+	prev time.Duration
+}
+
+// NextDelay implements Strategy.
+func (s *DecorrelatedJitter) NextDelay(_ int, _ *models.ExtractionRetry) (time.Duration, bool) {
+	base := s.Base
+	if base <= 0 {
+		base = time.Second
+	}
+	cap := s.Cap
+	if cap <= 0 {
+		cap = 60 * time.Second
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	prev := s.prev
+	if prev < base {
+		prev = base
+	}
+
+	upper := float64(prev) * 3
+	delay := time.Duration(float64(base) + rand.Float64()*(upper-float64(base)))
+	if delay > cap {
+		delay = cap
+	}
+	s.prev = delay
+	return delay, true
+}
+
+// FixedInterval retries at a constant interval.
+type FixedInterval struct {
+	Interval time.Duration
+}
+
+// NextDelay implements Strategy.
+func (s FixedInterval) NextDelay(_ int, _ *models.ExtractionRetry) (time.Duration, bool) {
+	if s.Interval <= 0 {
+		return time.Second, true
+	}
+	return s.Interval, true
+}
+
+// LinearBackoff increases the delay by a fixed increment on each attempt.
+type LinearBackoff struct {
+	Base      time.Duration
+	Increment time.Duration
+}
+
+// NextDelay implements Strategy.
+func (s LinearBackoff) NextDelay(attempt int, _ *models.ExtractionRetry) (time.Duration, bool) {
+	base := s.Base
+	if base <= 0 {
+		base = time.Second
+	}
+	return base + time.Duration(attempt-1)*s.Increment, true
+}
+
+// HTTPStatusAware wraps another Strategy and honors the HTTP semantics of
+// the last failure: it honors a stored Retry-After value outright, then
+// short-circuits (no retry) on 4xx responses other than 408 (timeout) and
+// 429 (rate limited), and otherwise defers to Inner.
+type HTTPStatusAware struct {
+	Inner Strategy
+}
+
+// NextDelay implements Strategy.
+func (s HTTPStatusAware) NextDelay(attempt int, lastErr *models.ExtractionRetry) (time.Duration, bool) {
+	if lastErr != nil {
+		// A Retry-After value is an explicit instruction from the server
+		// (typically alongside 429/503) and takes precedence over both the
+		// generic 4xx short-circuit below and Inner's own backoff math.
+		if lastErr.RetryAfterMs != nil {
+			return time.Duration(*lastErr.RetryAfterMs) * time.Millisecond, true
+		}
+		if lastErr.HTTPStatus != nil {
+			code := *lastErr.HTTPStatus
+			if code >= 400 && code < 500 && code != 408 && code != 429 {
+				return 0, false
+			}
+		}
+	}
+	inner := s.Inner
+	if inner == nil {
+		inner = ExponentialJitter{}
+	}
+	return inner.NextDelay(attempt, lastErr)
+}
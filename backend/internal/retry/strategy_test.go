@@ -0,0 +1,131 @@
+package retry
+
+import (
+	"testing"
+	"time"
+
+	"github.com/bartosz/stocks-out-for-harambe/backend/internal/models"
+)
+
+func intPtr(i int) *int { return &i }
+
+func TestExponentialJitter_BoundedByMax(t *testing.T) {
+	s := ExponentialJitter{Base: time.Second, Max: 5 * time.Second}
+	for attempt := 1; attempt <= 10; attempt++ {
+		delay, shouldRetry := s.NextDelay(attempt, nil)
+		if !shouldRetry {
+			t.Fatalf("attempt %d: expected shouldRetry true", attempt)
+		}
+		if delay < 0 || delay > 5*time.Second {
+			t.Errorf("attempt %d: delay %v out of bounds [0, 5s]", attempt, delay)
+		}
+	}
+}
+
+func TestExponentialJitter_Defaults(t *testing.T) {
+	s := ExponentialJitter{}
+	delay, shouldRetry := s.NextDelay(1, nil)
+	if !shouldRetry {
+		t.Fatal("expected shouldRetry true")
+	}
+	if delay < 0 || delay > 60*time.Second {
+		t.Errorf("expected delay within default max of 60s, got %v", delay)
+	}
+}
+
+func TestDecorrelatedJitter_StaysWithinCap(t *testing.T) {
+	s := &DecorrelatedJitter{Base: time.Second, Cap: 10 * time.Second}
+	for i := 0; i < 20; i++ {
+		delay, shouldRetry := s.NextDelay(i+1, nil)
+		if !shouldRetry {
+			t.Fatalf("iteration %d: expected shouldRetry true", i)
+		}
+		if delay > 10*time.Second {
+			t.Errorf("iteration %d: delay %v exceeds cap", i, delay)
+		}
+	}
+}
+
+func TestFixedInterval(t *testing.T) {
+	s := FixedInterval{Interval: 3 * time.Second}
+	delay, shouldRetry := s.NextDelay(5, nil)
+	if !shouldRetry || delay != 3*time.Second {
+		t.Errorf("got delay=%v shouldRetry=%v, want 3s/true", delay, shouldRetry)
+	}
+}
+
+func TestFixedInterval_DefaultsWhenUnset(t *testing.T) {
+	s := FixedInterval{}
+	delay, _ := s.NextDelay(1, nil)
+	if delay != time.Second {
+		t.Errorf("expected default 1s interval, got %v", delay)
+	}
+}
+
+func TestLinearBackoff_IncreasesWithAttempt(t *testing.T) {
+	s := LinearBackoff{Base: time.Second, Increment: 2 * time.Second}
+	d1, _ := s.NextDelay(1, nil)
+	d2, _ := s.NextDelay(2, nil)
+	d3, _ := s.NextDelay(3, nil)
+
+	if d1 != time.Second || d2 != 3*time.Second || d3 != 5*time.Second {
+		t.Errorf("got d1=%v d2=%v d3=%v, want 1s/3s/5s", d1, d2, d3)
+	}
+}
+
+func TestHTTPStatusAware_HonorsRetryAfter(t *testing.T) {
+	s := HTTPStatusAware{Inner: FixedInterval{Interval: time.Minute}}
+	lastErr := &models.ExtractionRetry{RetryAfterMs: intPtr(1500)}
+
+	delay, shouldRetry := s.NextDelay(1, lastErr)
+	if !shouldRetry {
+		t.Fatal("expected shouldRetry true when a Retry-After value is present")
+	}
+	if delay != 1500*time.Millisecond {
+		t.Errorf("expected delay to honor RetryAfterMs directly, got %v", delay)
+	}
+}
+
+func TestHTTPStatusAware_ShortCircuitsOn4xx(t *testing.T) {
+	s := HTTPStatusAware{Inner: FixedInterval{Interval: time.Minute}}
+	lastErr := &models.ExtractionRetry{HTTPStatus: intPtr(404)}
+
+	_, shouldRetry := s.NextDelay(1, lastErr)
+	if shouldRetry {
+		t.Error("expected a 404 to short-circuit retries")
+	}
+}
+
+func TestHTTPStatusAware_RetriesOn429And408(t *testing.T) {
+	s := HTTPStatusAware{Inner: FixedInterval{Interval: time.Minute}}
+
+	for _, code := range []int{408, 429} {
+		lastErr := &models.ExtractionRetry{HTTPStatus: intPtr(code)}
+		delay, shouldRetry := s.NextDelay(1, lastErr)
+		if !shouldRetry {
+			t.Errorf("status %d: expected shouldRetry true", code)
+		}
+		if delay != time.Minute {
+			t.Errorf("status %d: expected to fall through to Inner's fixed interval, got %v", code, delay)
+		}
+	}
+}
+
+func TestHTTPStatusAware_DefersToInnerWithoutLastErr(t *testing.T) {
+	s := HTTPStatusAware{Inner: FixedInterval{Interval: 42 * time.Second}}
+	delay, shouldRetry := s.NextDelay(1, nil)
+	if !shouldRetry || delay != 42*time.Second {
+		t.Errorf("got delay=%v shouldRetry=%v, want 42s/true", delay, shouldRetry)
+	}
+}
+
+func TestHTTPStatusAware_DefaultsInnerToExponentialJitter(t *testing.T) {
+	s := HTTPStatusAware{}
+	delay, shouldRetry := s.NextDelay(1, nil)
+	if !shouldRetry {
+		t.Fatal("expected shouldRetry true")
+	}
+	if delay < 0 || delay > 60*time.Second {
+		t.Errorf("expected delay within ExponentialJitter's default bounds, got %v", delay)
+	}
+}
@@ -0,0 +1,25 @@
+package search
+
+import (
+	"log"
+
+	"github.com/bartosz/stocks-out-for-harambe/backend/internal/config"
+	"github.com/bartosz/stocks-out-for-harambe/backend/internal/storage"
+)
+
+// BuildIndex selects the Index implementation configured by cfg: SQLIndex by
+// default, or ElasticIndex when cfg.Enabled and cfg.URL are both set. Falls
+// back to SQLIndex (logging a warning) if the Elasticsearch client can't be
+// constructed, so a misconfigured URL doesn't take search down entirely.
+func BuildIndex(cfg config.SearchConfig, db *storage.Database) Index {
+	if !cfg.Enabled || cfg.URL == "" {
+		return NewSQLIndex(db)
+	}
+
+	index, err := NewElasticIndex(cfg.URL, cfg.IndexPrefix)
+	if err != nil {
+		log.Printf("Warning: failed to initialize elasticsearch index, falling back to SQL: %v", err)
+		return NewSQLIndex(db)
+	}
+	return index
+}
@@ -0,0 +1,306 @@
+package search
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/bartosz/stocks-out-for-harambe/backend/internal/models"
+	"github.com/olivere/elastic/v7"
+)
+
+// elasticMaxRetries and elasticBaseBackoff mirror the retry shape used by
+// FirecrawlClient.makeRequest: exponential backoff on 429/5xx, no retry on
+// anything else.
+const (
+	elasticMaxRetries  = 3
+	elasticBaseBackoff = 1 * time.Second
+)
+
+// ElasticIndex implements Index against Elasticsearch via olivere/elastic,
+// for deployments with enough analyses that SQLIndex's group-bys stop
+// scaling.
+type ElasticIndex struct {
+	client      *elastic.Client
+	indexPrefix string
+}
+
+// NewElasticIndex creates an ElasticIndex talking to url, namespacing its
+// index under indexPrefix (e.g. "symbiot" -> "symbiot-analyses").
+func NewElasticIndex(url, indexPrefix string) (*ElasticIndex, error) {
+	client, err := elastic.NewClient(
+		elastic.SetURL(url),
+		elastic.SetSniff(false),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("search: failed to create elasticsearch client: %w", err)
+	}
+	return &ElasticIndex{client: client, indexPrefix: indexPrefix}, nil
+}
+
+func (e *ElasticIndex) analysesIndex() string {
+	return e.indexPrefix + "-analyses"
+}
+
+// analysisDoc is the Elasticsearch document shape for one TextAnalysis.
+type analysisDoc struct {
+	ID        uint      `json:"id"`
+	Text      string    `json:"text"`
+	Language  string    `json:"language"`
+	Sentiment string    `json:"sentiment"`
+	Score     float64   `json:"score"`
+	Keywords  []string  `json:"keywords"`
+	Entities  []string  `json:"entities"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+func toDoc(a *models.TextAnalysis) analysisDoc {
+	doc := analysisDoc{
+		ID:        a.ID,
+		Text:      a.Text,
+		Language:  a.Language,
+		Sentiment: a.Sentiment.Label,
+		Score:     a.Sentiment.Score,
+		CreatedAt: a.CreatedAt,
+	}
+	for _, k := range a.Keywords {
+		doc.Keywords = append(doc.Keywords, k.Word)
+	}
+	for _, en := range a.Entities {
+		doc.Entities = append(doc.Entities, en.Text)
+	}
+	return doc
+}
+
+// IndexAnalysis indexes a single analysis, retrying on 429/5xx with
+// exponential backoff.
+func (e *ElasticIndex) IndexAnalysis(ctx context.Context, analysis *models.TextAnalysis) error {
+	doc := toDoc(analysis)
+	return e.withRetry(ctx, func() error {
+		_, err := e.client.Index().
+			Index(e.analysesIndex()).
+			Id(fmt.Sprintf("%d", analysis.ID)).
+			BodyJson(doc).
+			Do(ctx)
+		return err
+	})
+}
+
+// BulkIndex indexes many analyses in one request, used by BatchAnalyzeText
+// and the reindex CLI.
+func (e *ElasticIndex) BulkIndex(ctx context.Context, analyses []models.TextAnalysis) error {
+	if len(analyses) == 0 {
+		return nil
+	}
+
+	return e.withRetry(ctx, func() error {
+		bulk := e.client.Bulk()
+		for i := range analyses {
+			doc := toDoc(&analyses[i])
+			bulk = bulk.Add(elastic.NewBulkIndexRequest().
+				Index(e.analysesIndex()).
+				Id(fmt.Sprintf("%d", doc.ID)).
+				Doc(doc))
+		}
+
+		resp, err := bulk.Do(ctx)
+		if err != nil {
+			return err
+		}
+		if resp.Errors {
+			return fmt.Errorf("search: bulk index reported per-item errors")
+		}
+		return nil
+	})
+}
+
+// Search runs a multi-match query over text, keywords, and entities.
+func (e *ElasticIndex) Search(ctx context.Context, q Query) ([]Result, error) {
+	page := q.Page
+	if page < 1 {
+		page = 1
+	}
+	limit := q.Limit
+	if limit <= 0 || limit > 100 {
+		limit = 20
+	}
+
+	esQuery := elastic.NewBoolQuery()
+	if q.Text != "" {
+		esQuery = esQuery.Must(elastic.NewMultiMatchQuery(q.Text, "text", "keywords", "entities"))
+	}
+	if q.Language != "" {
+		esQuery = esQuery.Filter(elastic.NewTermQuery("language", q.Language))
+	}
+	if q.Sentiment != "" {
+		esQuery = esQuery.Filter(elastic.NewTermQuery("sentiment", q.Sentiment))
+	}
+
+	var results []Result
+	err := e.withRetry(ctx, func() error {
+		resp, err := e.client.Search().
+			Index(e.analysesIndex()).
+			Query(esQuery).
+			From((page - 1) * limit).
+			Size(limit).
+			Do(ctx)
+		if err != nil {
+			return err
+		}
+
+		results = make([]Result, 0, len(resp.Hits.Hits))
+		for _, hit := range resp.Hits.Hits {
+			var doc analysisDoc
+			if err := json.Unmarshal(hit.Source, &doc); err != nil {
+				continue
+			}
+			var score float64
+			if hit.Score != nil {
+				score = *hit.Score
+			}
+			results = append(results, Result{ID: doc.ID, Text: doc.Text, Language: doc.Language, Sentiment: doc.Sentiment, Score: score})
+		}
+		return nil
+	})
+	return results, err
+}
+
+// KeywordAggregations runs a terms aggregation over the keywords field.
+func (e *ElasticIndex) KeywordAggregations(ctx context.Context, limit int) ([]KeywordAggregation, error) {
+	var aggs []KeywordAggregation
+	err := e.withRetry(ctx, func() error {
+		resp, err := e.client.Search().
+			Index(e.analysesIndex()).
+			Size(0).
+			Aggregation("keywords", elastic.NewTermsAggregation().Field("keywords.keyword").Size(limit)).
+			Do(ctx)
+		if err != nil {
+			return err
+		}
+
+		terms, found := resp.Aggregations.Terms("keywords")
+		if !found {
+			return nil
+		}
+		aggs = make([]KeywordAggregation, 0, len(terms.Buckets))
+		for _, b := range terms.Buckets {
+			aggs = append(aggs, KeywordAggregation{Word: fmt.Sprintf("%v", b.Key), Frequency: int(b.DocCount)})
+		}
+		return nil
+	})
+	return aggs, err
+}
+
+// EntityAggregations runs a terms aggregation over the entities field.
+// Elasticsearch's document shape here doesn't carry entity type separately
+// from its text, so entityType filtering isn't applied — callers that need
+// it should prefer SQLIndex, or this can grow a keyed sub-aggregation once
+// the entity doc shape carries type.
+func (e *ElasticIndex) EntityAggregations(ctx context.Context, entityType string, limit int) ([]EntityAggregation, error) {
+	var aggs []EntityAggregation
+	err := e.withRetry(ctx, func() error {
+		resp, err := e.client.Search().
+			Index(e.analysesIndex()).
+			Size(0).
+			Aggregation("entities", elastic.NewTermsAggregation().Field("entities.keyword").Size(limit)).
+			Do(ctx)
+		if err != nil {
+			return err
+		}
+
+		terms, found := resp.Aggregations.Terms("entities")
+		if !found {
+			return nil
+		}
+		aggs = make([]EntityAggregation, 0, len(terms.Buckets))
+		for _, b := range terms.Buckets {
+			aggs = append(aggs, EntityAggregation{Text: fmt.Sprintf("%v", b.Key), Frequency: int(b.DocCount)})
+		}
+		return nil
+	})
+	return aggs, err
+}
+
+// SentimentTrend runs a date histogram aggregation with a nested average
+// sentiment-score sub-aggregation.
+func (e *ElasticIndex) SentimentTrend(ctx context.Context, bucket time.Duration, limit int) ([]SentimentBucket, error) {
+	interval := dateTruncUnit(bucket)
+
+	var buckets []SentimentBucket
+	err := e.withRetry(ctx, func() error {
+		dateHisto := elastic.NewDateHistogramAggregation().
+			Field("created_at").
+			CalendarInterval(interval).
+			SubAggregation("avg_score", elastic.NewAvgAggregation().Field("score"))
+
+		resp, err := e.client.Search().
+			Index(e.analysesIndex()).
+			Size(0).
+			Aggregation("trend", dateHisto).
+			Do(ctx)
+		if err != nil {
+			return err
+		}
+
+		histo, found := resp.Aggregations.DateHistogram("trend")
+		if !found {
+			return nil
+		}
+		for _, b := range histo.Buckets {
+			avg, _ := b.Avg("avg_score")
+			var average float64
+			if avg != nil && avg.Value != nil {
+				average = *avg.Value
+			}
+			buckets = append(buckets, SentimentBucket{
+				BucketStart: time.UnixMilli(int64(b.Key)),
+				Average:     average,
+				Count:       int(b.DocCount),
+			})
+			if len(buckets) >= limit {
+				break
+			}
+		}
+		return nil
+	})
+	return buckets, err
+}
+
+// withRetry retries fn with exponential backoff on 429/5xx responses,
+// mirroring FirecrawlClient.makeRequest.
+func (e *ElasticIndex) withRetry(ctx context.Context, fn func() error) error {
+	var lastErr error
+	for attempt := 0; attempt <= elasticMaxRetries; attempt++ {
+		if attempt > 0 {
+			waitTime := elasticBaseBackoff * time.Duration(1<<uint(attempt-1))
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-time.After(waitTime):
+			}
+		}
+
+		err := fn()
+		if err == nil {
+			return nil
+		}
+		lastErr = err
+
+		if elastic.IsStatusCode(err, http.StatusTooManyRequests) || isElasticServerError(err) {
+			continue
+		}
+		return err
+	}
+	return fmt.Errorf("search: elasticsearch request failed after %d retries: %w", elasticMaxRetries, lastErr)
+}
+
+func isElasticServerError(err error) bool {
+	for code := 500; code < 600; code++ {
+		if elastic.IsStatusCode(err, code) {
+			return true
+		}
+	}
+	return false
+}
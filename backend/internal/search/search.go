@@ -0,0 +1,77 @@
+// Package search defines the full-text search and aggregation backend for
+// TextAnalysis records. TextHandler depends on the Index interface rather
+// than a concrete implementation, the same way the audit subsystem depends
+// on a Sink rather than a specific backend — SQLIndex is the default, and
+// ElasticIndex takes over once a deployment's analysis volume outgrows what
+// Postgres group-bys handle well.
+package search
+
+import (
+	"context"
+	"time"
+
+	"github.com/bartosz/stocks-out-for-harambe/backend/internal/models"
+)
+
+// Query describes a full-text search request against indexed analyses.
+type Query struct {
+	Text      string
+	Language  string
+	Sentiment string
+	Page      int
+	Limit     int
+}
+
+// Result is one matched TextAnalysis, as returned by Search.
+type Result struct {
+	ID        uint    `json:"id"`
+	Text      string  `json:"text"`
+	Language  string  `json:"language"`
+	Sentiment string  `json:"sentiment"`
+	Score     float64 `json:"score"`
+}
+
+// KeywordAggregation summarizes one word's frequency across all indexed
+// analyses.
+type KeywordAggregation struct {
+	Word      string  `json:"word"`
+	Frequency int     `json:"frequency"`
+	Relevance float64 `json:"relevance"`
+}
+
+// EntityAggregation summarizes one entity's frequency across all indexed
+// analyses.
+type EntityAggregation struct {
+	Text       string  `json:"text"`
+	Type       string  `json:"type"`
+	Frequency  int     `json:"frequency"`
+	Confidence float64 `json:"confidence"`
+}
+
+// SentimentBucket is one time bucket's average sentiment score, for trend
+// charts.
+type SentimentBucket struct {
+	BucketStart time.Time `json:"bucket_start"`
+	Average     float64   `json:"average"`
+	Count       int       `json:"count"`
+}
+
+// Index indexes TextAnalysis records for full-text search and aggregation.
+type Index interface {
+	// IndexAnalysis makes one analysis searchable. Called right after it's
+	// persisted to Postgres, the same point audit.Record is called from.
+	IndexAnalysis(ctx context.Context, analysis *models.TextAnalysis) error
+	// BulkIndex indexes many analyses at once, used by BatchAnalyzeText and
+	// the reindex CLI.
+	BulkIndex(ctx context.Context, analyses []models.TextAnalysis) error
+	// Search performs a full-text search over indexed analyses.
+	Search(ctx context.Context, q Query) ([]Result, error)
+	// KeywordAggregations returns the limit most frequent keywords.
+	KeywordAggregations(ctx context.Context, limit int) ([]KeywordAggregation, error)
+	// EntityAggregations returns the limit most frequent entities, optionally
+	// filtered by entityType.
+	EntityAggregations(ctx context.Context, entityType string, limit int) ([]EntityAggregation, error)
+	// SentimentTrend buckets average sentiment score over time, rounding
+	// bucket down to the nearest supported granularity (hour/day/week).
+	SentimentTrend(ctx context.Context, bucket time.Duration, limit int) ([]SentimentBucket, error)
+}
@@ -0,0 +1,138 @@
+package search
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/bartosz/stocks-out-for-harambe/backend/internal/models"
+	"github.com/bartosz/stocks-out-for-harambe/backend/internal/storage"
+)
+
+// SQLIndex implements Index directly against the Postgres tables GORM
+// already populates, with no separate write path to keep consistent. It's
+// the default backend and what BuildIndex returns when Elasticsearch isn't
+// configured.
+type SQLIndex struct {
+	db *storage.Database
+}
+
+// NewSQLIndex creates a SQLIndex backed by db.
+func NewSQLIndex(db *storage.Database) *SQLIndex {
+	return &SQLIndex{db: db}
+}
+
+// IndexAnalysis is a no-op: the row is already in Postgres by the time
+// TextHandler calls this.
+func (s *SQLIndex) IndexAnalysis(ctx context.Context, analysis *models.TextAnalysis) error {
+	return nil
+}
+
+// BulkIndex is a no-op for the same reason as IndexAnalysis.
+func (s *SQLIndex) BulkIndex(ctx context.Context, analyses []models.TextAnalysis) error {
+	return nil
+}
+
+// Search performs a case-insensitive substring match over Text, optionally
+// filtered by language and sentiment label. It's an O(N) sequential scan at
+// Postgres scale, acceptable for the dataset sizes SQLIndex targets.
+func (s *SQLIndex) Search(ctx context.Context, q Query) ([]Result, error) {
+	page := q.Page
+	if page < 1 {
+		page = 1
+	}
+	limit := q.Limit
+	if limit <= 0 || limit > 100 {
+		limit = 20
+	}
+
+	query := s.db.WithContext(ctx).Model(&models.TextAnalysis{})
+	if q.Text != "" {
+		query = query.Where("text ILIKE ?", "%"+q.Text+"%")
+	}
+	if q.Language != "" {
+		query = query.Where("language = ?", q.Language)
+	}
+	if q.Sentiment != "" {
+		query = query.Where("sentiment_label = ?", q.Sentiment)
+	}
+
+	var analyses []models.TextAnalysis
+	if err := query.Order("created_at DESC").Offset((page - 1) * limit).Limit(limit).Find(&analyses).Error; err != nil {
+		return nil, fmt.Errorf("search: %w", err)
+	}
+
+	results := make([]Result, len(analyses))
+	for i, a := range analyses {
+		results[i] = Result{ID: a.ID, Text: a.Text, Language: a.Language, Sentiment: a.Sentiment.Label}
+	}
+	return results, nil
+}
+
+// KeywordAggregations runs the same SUM/AVG group-by TextHandler used to
+// use inline.
+func (s *SQLIndex) KeywordAggregations(ctx context.Context, limit int) ([]KeywordAggregation, error) {
+	var keywords []KeywordAggregation
+	err := s.db.WithContext(ctx).Model(&models.Keyword{}).
+		Select("word, SUM(frequency) as frequency, AVG(relevance) as relevance").
+		Group("word").
+		Order("frequency DESC").
+		Limit(limit).
+		Find(&keywords).Error
+	if err != nil {
+		return nil, fmt.Errorf("keyword aggregations: %w", err)
+	}
+	return keywords, nil
+}
+
+// EntityAggregations runs the same COUNT/AVG group-by TextHandler used to
+// use inline, optionally filtered by entityType.
+func (s *SQLIndex) EntityAggregations(ctx context.Context, entityType string, limit int) ([]EntityAggregation, error) {
+	query := s.db.WithContext(ctx).Model(&models.Entity{}).
+		Select("text, type, COUNT(*) as frequency, AVG(confidence) as confidence").
+		Group("text, type").
+		Order("frequency DESC").
+		Limit(limit)
+
+	if entityType != "" {
+		query = query.Where("type = ?", entityType)
+	}
+
+	var entities []EntityAggregation
+	if err := query.Find(&entities).Error; err != nil {
+		return nil, fmt.Errorf("entity aggregations: %w", err)
+	}
+	return entities, nil
+}
+
+// SentimentTrend buckets average sentiment_score by created_at via
+// date_trunc, rounding bucket down to the closest Postgres unit since
+// date_trunc only accepts fixed unit names, not arbitrary durations.
+func (s *SQLIndex) SentimentTrend(ctx context.Context, bucket time.Duration, limit int) ([]SentimentBucket, error) {
+	unit := dateTruncUnit(bucket)
+
+	var buckets []SentimentBucket
+	err := s.db.WithContext(ctx).Model(&models.TextAnalysis{}).
+		Select(fmt.Sprintf("date_trunc('%s', created_at) as bucket_start, AVG(sentiment_score) as average, COUNT(*) as count", unit)).
+		Group("bucket_start").
+		Order("bucket_start DESC").
+		Limit(limit).
+		Find(&buckets).Error
+	if err != nil {
+		return nil, fmt.Errorf("sentiment trend: %w", err)
+	}
+	return buckets, nil
+}
+
+// dateTruncUnit maps bucket down to the closest date_trunc-supported unit;
+// buckets under an hour use "hour", the smallest unit offered.
+func dateTruncUnit(bucket time.Duration) string {
+	switch {
+	case bucket < 24*time.Hour:
+		return "hour"
+	case bucket < 7*24*time.Hour:
+		return "day"
+	default:
+		return "week"
+	}
+}
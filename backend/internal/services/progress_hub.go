@@ -0,0 +1,106 @@
+package services
+
+import (
+	"sync"
+)
+
+// ExtractionEventType identifies the kind of payload carried by an
+// ExtractionEvent on the progress stream.
+type ExtractionEventType string
+
+const (
+	// ExtractionEventProgress carries updated successful/failed/total counters.
+	ExtractionEventProgress ExtractionEventType = "progress"
+	// ExtractionEventURLCompleted fires when a single URLExtraction finishes.
+	ExtractionEventURLCompleted ExtractionEventType = "url_completed"
+	// ExtractionEventRetry fires when a URLExtraction is retried.
+	ExtractionEventRetry ExtractionEventType = "retry"
+	// ExtractionEventURLFailed fires when a single URLExtraction exhausts
+	// its attempts and settles into a failed terminal state.
+	ExtractionEventURLFailed ExtractionEventType = "url_failed"
+	// ExtractionEventStatusChanged fires when the session itself transitions
+	// status (e.g. paused, resumed, cancelled) outside of completion.
+	ExtractionEventStatusChanged ExtractionEventType = "status_changed"
+	// ExtractionEventSessionCompleted fires once and ends the stream.
+	ExtractionEventSessionCompleted ExtractionEventType = "session_completed"
+)
+
+// ExtractionEvent is a single message broadcast to subscribers of a session's
+// progress stream.
+type ExtractionEvent struct {
+	Type      ExtractionEventType `json:"type"`
+	SessionID string              `json:"session_id"`
+	Data      interface{}         `json:"data"`
+}
+
+// subscriberBuffer is the per-subscriber channel depth. Slow consumers drop
+// events rather than blocking publishers, since the SSE handler always sends
+// a fresh DB snapshot on connect.
+const subscriberBuffer = 16
+
+// ProgressHub is a broadcast hub that fans extraction events out to every
+// subscriber of a given session, so N browser tabs watching the same
+// extraction share one underlying feed instead of each polling Postgres.
+type ProgressHub struct {
+	mu   sync.RWMutex
+	subs map[string]map[chan ExtractionEvent]struct{}
+}
+
+// NewProgressHub creates an empty ProgressHub.
+func NewProgressHub() *ProgressHub {
+	return &ProgressHub{
+		subs: make(map[string]map[chan ExtractionEvent]struct{}),
+	}
+}
+
+// Subscribe registers a new listener for a session's events. The returned
+// func must be called to unregister the listener and release its channel.
+func (h *ProgressHub) Subscribe(sessionID string) (<-chan ExtractionEvent, func()) {
+	ch := make(chan ExtractionEvent, subscriberBuffer)
+
+	h.mu.Lock()
+	if h.subs[sessionID] == nil {
+		h.subs[sessionID] = make(map[chan ExtractionEvent]struct{})
+	}
+	h.subs[sessionID][ch] = struct{}{}
+	h.mu.Unlock()
+
+	unsubscribe := func() {
+		h.mu.Lock()
+		defer h.mu.Unlock()
+		if set, ok := h.subs[sessionID]; ok {
+			delete(set, ch)
+			if len(set) == 0 {
+				delete(h.subs, sessionID)
+			}
+		}
+		close(ch)
+	}
+
+	return ch, unsubscribe
+}
+
+// Publish broadcasts an event to every current subscriber of sessionID.
+// Subscribers whose buffer is full are skipped rather than blocking the
+// publisher, which keeps a stalled client from stalling the extraction.
+func (h *ProgressHub) Publish(sessionID string, event ExtractionEvent) {
+	event.SessionID = sessionID
+
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	for ch := range h.subs[sessionID] {
+		select {
+		case ch <- event:
+		default:
+		}
+	}
+}
+
+// HasSubscribers reports whether any client is currently listening to a
+// session's stream, letting callers skip event construction entirely.
+func (h *ProgressHub) HasSubscribers(sessionID string) bool {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	return len(h.subs[sessionID]) > 0
+}
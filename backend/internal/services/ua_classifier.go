@@ -0,0 +1,274 @@
+package services
+
+import "strings"
+
+// Browser is a recognized browser family, akin to uasurfer's Browser enum.
+type Browser string
+
+const (
+	BrowserChrome  Browser = "chrome"
+	BrowserFirefox Browser = "firefox"
+	BrowserSafari  Browser = "safari"
+	BrowserEdge    Browser = "edge"
+	BrowserIE      Browser = "ie"
+	BrowserOpera   Browser = "opera"
+	BrowserUnknown Browser = "unknown"
+)
+
+// OS is a recognized operating system family.
+type OS string
+
+const (
+	OSWindows OS = "windows"
+	OSMacOS   OS = "macos"
+	OSLinux   OS = "linux"
+	OSAndroid OS = "android"
+	OSiOS     OS = "ios"
+	OSUnknown OS = "unknown"
+)
+
+// DeviceClass is the form factor a User-Agent claims to be running on.
+type DeviceClass string
+
+const (
+	DeviceDesktop DeviceClass = "desktop"
+	DeviceMobile  DeviceClass = "mobile"
+	DeviceTablet  DeviceClass = "tablet"
+	DeviceBot     DeviceClass = "bot"
+	DeviceUnknown DeviceClass = "unknown"
+)
+
+// BotFamily identifies a known automated client. ScriptedClient covers
+// generic HTTP library defaults (curl, Go's net/http, requests, ...) that
+// aren't a named crawler but clearly aren't a browser either.
+type BotFamily string
+
+const (
+	BotGooglebot      BotFamily = "googlebot"
+	BotBingbot        BotFamily = "bingbot"
+	BotYandexBot      BotFamily = "yandexbot"
+	BotBaiduspider    BotFamily = "baiduspider"
+	BotSogou          BotFamily = "sogou"
+	BotBytespider     BotFamily = "bytespider" // Toutiao/TikTok's crawler
+	BotDuckDuckBot    BotFamily = "duckduckbot"
+	BotQwantify       BotFamily = "qwantify"
+	BotSemrushBot     BotFamily = "semrushbot"
+	BotAhrefsBot      BotFamily = "ahrefsbot"
+	BotMJ12Bot        BotFamily = "mj12bot"
+	BotSlackbot       BotFamily = "slackbot"
+	BotDiscordbot     BotFamily = "discordbot"
+	BotTelegramBot    BotFamily = "telegrambot"
+	BotTwitterbot     BotFamily = "twitterbot"
+	BotFacebookHit    BotFamily = "facebookexternalhit"
+	BotArchiveOrg     BotFamily = "archive.org_bot"
+	BotUptimeRobot    BotFamily = "uptimerobot"
+	BotPingdom        BotFamily = "pingdom"
+	BotScriptedClient BotFamily = "scripted_client"
+	BotFamilyOther    BotFamily = "other"
+	BotFamilyNone     BotFamily = ""
+)
+
+// botSignatures maps a case-insensitive substring of the User-Agent header
+// to the bot family it identifies. Checked in order, so more specific
+// entries (e.g. "bytespider") are listed ahead of generic ones.
+var botSignatures = []struct {
+	substr string
+	family BotFamily
+}{
+	{"googlebot", BotGooglebot},
+	{"bingbot", BotBingbot},
+	{"yandexbot", BotYandexBot},
+	{"baiduspider", BotBaiduspider},
+	{"sogou", BotSogou},
+	{"bytespider", BotBytespider},
+	{"duckduckbot", BotDuckDuckBot},
+	{"qwantify", BotQwantify},
+	{"semrushbot", BotSemrushBot},
+	{"ahrefsbot", BotAhrefsBot},
+	{"mj12bot", BotMJ12Bot},
+	{"slackbot", BotSlackbot},
+	{"discordbot", BotDiscordbot},
+	{"telegrambot", BotTelegramBot},
+	{"twitterbot", BotTwitterbot},
+	{"facebookexternalhit", BotFacebookHit},
+	{"archive.org_bot", BotArchiveOrg},
+	{"ia_archiver", BotArchiveOrg},
+	{"uptimerobot", BotUptimeRobot},
+	{"pingdom", BotPingdom},
+}
+
+// scriptedClientSignatures identifies generic HTTP library/tool defaults
+// that are automated but aren't a named crawler worth its own BotFamily.
+var scriptedClientSignatures = []string{
+	"curl/", "wget/", "python-requests", "python-urllib", "go-http-client",
+	"okhttp", "axios/", "postmanruntime", "libwww-perl", "java/", "apache-httpclient",
+}
+
+// maxSaneUserAgentLength bounds what counts as a "malformed" User-Agent
+// rather than an unusually long but legitimate one.
+const maxSaneUserAgentLength = 1024
+
+// suspiciousSubstrings are markers of a User-Agent that looks like an
+// injection attempt rather than any real client identifying itself.
+var suspiciousSubstrings = []string{
+	"<script", "select ", "union ", "drop table", "../", "%00",
+}
+
+// Category buckets a classified User-Agent by how much to trust it.
+type Category string
+
+const (
+	CategoryBrowser    Category = "browser"
+	CategoryBot        Category = "bot"
+	CategoryBlank      Category = "blank"
+	CategoryMalformed  Category = "malformed"
+	CategorySuspicious Category = "suspicious"
+)
+
+// Classification is the result of classifying one User-Agent string.
+type Classification struct {
+	Raw       string      `json:"raw"`
+	Category  Category    `json:"category"`
+	Browser   Browser     `json:"browser,omitempty"`
+	OS        OS          `json:"os,omitempty"`
+	Device    DeviceClass `json:"device,omitempty"`
+	BotFamily BotFamily   `json:"bot_family,omitempty"`
+}
+
+// UAClassifier classifies raw User-Agent header values into a browser/OS/
+// device triple for ordinary clients, a BotFamily for known crawlers and
+// monitoring services, or one of the blank/malformed/suspicious categories
+// for agents that don't look like a genuine client at all. It's a small,
+// embedded uasurfer-style parser (substring signatures over the handful of
+// fields this codebase actually needs) rather than a full UA database.
+type UAClassifier struct{}
+
+// NewUAClassifier creates a UAClassifier. It holds no state, so a single
+// instance can be shared across goroutines and request handlers.
+func NewUAClassifier() *UAClassifier {
+	return &UAClassifier{}
+}
+
+// Classify inspects a raw User-Agent header value and returns its
+// Classification. An empty or whitespace-only ua is CategoryBlank; one that
+// is too long or contains non-printable or non-ASCII characters is
+// CategoryMalformed;
+// one containing literal injection markers, or matching no known browser,
+// OS, or bot signature, is CategorySuspicious; everything else is
+// classified as CategoryBot or CategoryBrowser.
+func (c *UAClassifier) Classify(ua string) Classification {
+	trimmed := strings.TrimSpace(ua)
+	result := Classification{Raw: ua}
+
+	if trimmed == "" {
+		result.Category = CategoryBlank
+		return result
+	}
+
+	if len(trimmed) > maxSaneUserAgentLength || !isPrintableASCII(trimmed) {
+		result.Category = CategoryMalformed
+		return result
+	}
+
+	lower := strings.ToLower(trimmed)
+
+	for _, marker := range suspiciousSubstrings {
+		if strings.Contains(lower, marker) {
+			result.Category = CategorySuspicious
+			return result
+		}
+	}
+
+	for _, sig := range botSignatures {
+		if strings.Contains(lower, sig.substr) {
+			result.Category = CategoryBot
+			result.BotFamily = sig.family
+			result.Device = DeviceBot
+			return result
+		}
+	}
+
+	for _, sig := range scriptedClientSignatures {
+		if strings.Contains(lower, sig) {
+			result.Category = CategoryBot
+			result.BotFamily = BotScriptedClient
+			result.Device = DeviceBot
+			return result
+		}
+	}
+
+	browser := classifyBrowser(lower)
+	os := classifyOS(lower)
+	device := classifyDevice(lower)
+
+	if browser == BrowserUnknown && os == OSUnknown {
+		// Doesn't match a known browser, OS, or bot/tool signature at all:
+		// flag it rather than silently bucketing it as a plain browser.
+		result.Category = CategorySuspicious
+		return result
+	}
+
+	result.Category = CategoryBrowser
+	result.Browser = browser
+	result.OS = os
+	result.Device = device
+	return result
+}
+
+func classifyBrowser(lower string) Browser {
+	switch {
+	case strings.Contains(lower, "edg/") || strings.Contains(lower, "edge/"):
+		return BrowserEdge
+	case strings.Contains(lower, "opr/") || strings.Contains(lower, "opera"):
+		return BrowserOpera
+	case strings.Contains(lower, "firefox/"):
+		return BrowserFirefox
+	case strings.Contains(lower, "trident/") || strings.Contains(lower, "msie "):
+		return BrowserIE
+	case strings.Contains(lower, "chrome/"):
+		return BrowserChrome
+	case strings.Contains(lower, "safari/") && strings.Contains(lower, "version/"):
+		return BrowserSafari
+	default:
+		return BrowserUnknown
+	}
+}
+
+func classifyOS(lower string) OS {
+	switch {
+	case strings.Contains(lower, "windows"):
+		return OSWindows
+	case strings.Contains(lower, "iphone") || strings.Contains(lower, "ipad") || strings.Contains(lower, "ios "):
+		return OSiOS
+	case strings.Contains(lower, "mac os x") || strings.Contains(lower, "macintosh"):
+		return OSMacOS
+	case strings.Contains(lower, "android"):
+		return OSAndroid
+	case strings.Contains(lower, "linux"):
+		return OSLinux
+	default:
+		return OSUnknown
+	}
+}
+
+func classifyDevice(lower string) DeviceClass {
+	switch {
+	case strings.Contains(lower, "ipad") || (strings.Contains(lower, "android") && !strings.Contains(lower, "mobile")):
+		return DeviceTablet
+	case strings.Contains(lower, "iphone") || strings.Contains(lower, "mobile"):
+		return DeviceMobile
+	case strings.Contains(lower, "windows") || strings.Contains(lower, "macintosh") || strings.Contains(lower, "mac os x") || strings.Contains(lower, "linux"):
+		return DeviceDesktop
+	default:
+		return DeviceUnknown
+	}
+}
+
+func isPrintableASCII(s string) bool {
+	for _, r := range s {
+		if r < 0x20 || r > 0x7e {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,253 @@
+// Package sitemapdiscovery implements the sitemaps.org protocol: robots.txt
+// "Sitemap:" discovery, recursive sitemap-index traversal, and transparent
+// gzip decoding. It fetches sitemaps directly rather than delegating to the
+// firecrawl service, since sitemap parsing is plain XML and doesn't need a
+// headless browser.
+package sitemapdiscovery
+
+import (
+	"compress/gzip"
+	"context"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// DefaultMaxDepth bounds how many levels of <sitemapindex> nesting are
+// followed before giving up on a branch.
+const DefaultMaxDepth = 3
+
+// DefaultMaxURLs bounds the total number of URLs collected across the whole
+// tree, so a misbehaving or hostile sitemap can't exhaust memory.
+const DefaultMaxURLs = 50000
+
+// URLEntry is one <url> entry from a <urlset> sitemap.
+type URLEntry struct {
+	Loc        string `json:"loc"`
+	LastMod    string `json:"lastmod,omitempty"`
+	ChangeFreq string `json:"changefreq,omitempty"`
+	Priority   string `json:"priority,omitempty"`
+}
+
+// Node is one fetched sitemap in the discovery tree: either a <urlset> leaf
+// (URLs populated) or a <sitemapindex> with Children, or neither if Error
+// is set, so one failed branch doesn't abort the rest of the tree.
+type Node struct {
+	SitemapURL string     `json:"sitemap_url"`
+	Kind       string     `json:"kind"` // "urlset" | "sitemapindex"
+	URLs       []URLEntry `json:"urls,omitempty"`
+	Children   []*Node    `json:"children,omitempty"`
+	Error      string     `json:"error,omitempty"`
+}
+
+// Result is the outcome of a full discovery run.
+type Result struct {
+	BaseURL       string  `json:"base_url"`
+	RobotsFound   bool    `json:"robots_found"`
+	Roots         []*Node `json:"roots"`
+	TotalURLs     int     `json:"total_urls"`
+	TruncatedURLs bool    `json:"truncated_urls"`
+}
+
+// urlsetXML and sitemapIndexXML mirror the sitemaps.org XSD closely enough
+// to round-trip the fields this package cares about.
+type urlsetXML struct {
+	XMLName xml.Name  `xml:"urlset"`
+	URLs    []urlItem `xml:"url"`
+}
+
+type urlItem struct {
+	Loc        string `xml:"loc"`
+	LastMod    string `xml:"lastmod"`
+	ChangeFreq string `xml:"changefreq"`
+	Priority   string `xml:"priority"`
+}
+
+type sitemapIndexXML struct {
+	XMLName  xml.Name     `xml:"sitemapindex"`
+	Sitemaps []sitemapRef `xml:"sitemap"`
+}
+
+type sitemapRef struct {
+	Loc string `xml:"loc"`
+}
+
+// Discoverer fetches robots.txt and sitemaps over HTTP. It's a struct (not
+// package functions) so the HTTP client and budget fields can be overridden
+// in isolation without a global.
+type Discoverer struct {
+	HTTPClient *http.Client
+	MaxDepth   int
+	MaxURLs    int
+}
+
+// New creates a Discoverer with the package defaults.
+func New(client *http.Client) *Discoverer {
+	if client == nil {
+		client = http.DefaultClient
+	}
+	return &Discoverer{HTTPClient: client, MaxDepth: DefaultMaxDepth, MaxURLs: DefaultMaxURLs}
+}
+
+// Discover runs the full protocol against baseURL: robots.txt first, falling
+// back to /sitemap.xml if robots.txt has no Sitemap: directives, then
+// recursing into any sitemap indexes it finds.
+func (d *Discoverer) Discover(ctx context.Context, baseURL string) (*Result, error) {
+	maxDepth := d.MaxDepth
+	if maxDepth <= 0 {
+		maxDepth = DefaultMaxDepth
+	}
+	maxURLs := d.MaxURLs
+	if maxURLs <= 0 {
+		maxURLs = DefaultMaxURLs
+	}
+
+	parsed, err := url.Parse(baseURL)
+	if err != nil {
+		return nil, fmt.Errorf("sitemapdiscovery: invalid base_url: %w", err)
+	}
+
+	result := &Result{BaseURL: baseURL}
+
+	sitemapURLs, err := d.fetchRobotsSitemaps(ctx, parsed)
+	if err == nil && len(sitemapURLs) > 0 {
+		result.RobotsFound = true
+	} else {
+		sitemapURLs = []string{strings.TrimRight(baseURL, "/") + "/sitemap.xml"}
+	}
+
+	collected := 0
+	for _, sitemapURL := range sitemapURLs {
+		node := d.fetchNode(ctx, sitemapURL, 1, maxDepth, maxURLs, &collected)
+		result.Roots = append(result.Roots, node)
+	}
+	result.TotalURLs = collected
+	result.TruncatedURLs = collected >= maxURLs
+
+	return result, nil
+}
+
+// fetchRobotsSitemaps fetches /robots.txt and returns every "Sitemap:"
+// directive it finds.
+func (d *Discoverer) fetchRobotsSitemaps(ctx context.Context, base *url.URL) ([]string, error) {
+	robotsURL := fmt.Sprintf("%s://%s/robots.txt", base.Scheme, base.Host)
+	body, err := d.fetch(ctx, robotsURL)
+	if err != nil {
+		return nil, err
+	}
+
+	var sitemaps []string
+	for _, line := range strings.Split(string(body), "\n") {
+		line = strings.TrimSpace(line)
+		if !strings.HasPrefix(strings.ToLower(line), "sitemap:") {
+			continue
+		}
+		// The value itself contains "://", so only split on the first colon.
+		idx := strings.Index(line, ":")
+		sitemaps = append(sitemaps, strings.TrimSpace(line[idx+1:]))
+	}
+	return sitemaps, nil
+}
+
+// fetchNode fetches and parses one sitemap URL, recursing into child
+// sitemaps if it's a <sitemapindex>, bounded by depth and the URL budget.
+func (d *Discoverer) fetchNode(ctx context.Context, sitemapURL string, depth, maxDepth, maxURLs int, collected *int) *Node {
+	node := &Node{SitemapURL: sitemapURL}
+
+	body, err := d.fetch(ctx, sitemapURL)
+	if err != nil {
+		node.Error = err.Error()
+		return node
+	}
+
+	if *collected >= maxURLs {
+		node.Error = "max_urls budget exhausted before this sitemap was parsed"
+		return node
+	}
+
+	var index sitemapIndexXML
+	if err := xml.Unmarshal(body, &index); err == nil && len(index.Sitemaps) > 0 {
+		node.Kind = "sitemapindex"
+		if depth >= maxDepth {
+			node.Error = fmt.Sprintf("max_depth (%d) reached; %d child sitemaps not followed", maxDepth, len(index.Sitemaps))
+			return node
+		}
+		for _, child := range index.Sitemaps {
+			if *collected >= maxURLs {
+				break
+			}
+			node.Children = append(node.Children, d.fetchNode(ctx, child.Loc, depth+1, maxDepth, maxURLs, collected))
+		}
+		return node
+	}
+
+	var set urlsetXML
+	if err := xml.Unmarshal(body, &set); err != nil {
+		node.Error = fmt.Sprintf("failed to parse as sitemapindex or urlset: %v", err)
+		return node
+	}
+
+	node.Kind = "urlset"
+	for _, u := range set.URLs {
+		if *collected >= maxURLs {
+			break
+		}
+		node.URLs = append(node.URLs, URLEntry{
+			Loc:        u.Loc,
+			LastMod:    u.LastMod,
+			ChangeFreq: u.ChangeFreq,
+			Priority:   u.Priority,
+		})
+		*collected++
+	}
+	return node
+}
+
+// FlattenURLs walks a discovery tree depth-first and returns every leaf
+// URL's Loc, in the order the sitemaps were fetched.
+func FlattenURLs(nodes []*Node) []string {
+	var urls []string
+	for _, n := range nodes {
+		for _, u := range n.URLs {
+			urls = append(urls, u.Loc)
+		}
+		urls = append(urls, FlattenURLs(n.Children)...)
+	}
+	return urls
+}
+
+// fetch retrieves a URL and transparently gunzips it, either because the
+// URL ends in .xml.gz or the server sent Content-Encoding: gzip (which
+// net/http's default transport already strips for non-gzip-requested
+// responses, so this covers the explicit .gz-over-plain-transport case).
+func (d *Discoverer) fetch(ctx context.Context, rawURL string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, rawURL, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := d.HTTPClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 400 {
+		return nil, fmt.Errorf("sitemapdiscovery: %s returned HTTP %d", rawURL, resp.StatusCode)
+	}
+
+	var reader io.Reader = resp.Body
+	if strings.HasSuffix(strings.ToLower(rawURL), ".gz") || resp.Header.Get("Content-Encoding") == "gzip" {
+		gz, err := gzip.NewReader(resp.Body)
+		if err != nil {
+			return nil, fmt.Errorf("sitemapdiscovery: failed to gunzip %s: %w", rawURL, err)
+		}
+		defer gz.Close()
+		reader = gz
+	}
+
+	return io.ReadAll(reader)
+}
@@ -1,18 +1,14 @@
 package storage
 
 import (
-	"database/sql"
 	"fmt"
 	"log"
 	"time"
 
 	"github.com/bartosz/stocks-out-for-harambe/backend/internal/config"
-	"github.com/golang-migrate/migrate/v4"
-	"github.com/golang-migrate/migrate/v4/database/postgres"
-	_ "github.com/golang-migrate/migrate/v4/source/file"
-	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
 	"gorm.io/gorm/logger"
+	"gorm.io/plugin/dbresolver"
 )
 
 // Database wraps the GORM v2 database connection
@@ -20,13 +16,15 @@ type Database struct {
 	*gorm.DB
 }
 
-// NewDatabase creates a new database connection (GORM v2)
+// NewDatabase creates a new database connection (GORM v2), using whichever
+// Driver cfg.Driver selects. If cfg.ReadReplicas is non-empty, it also
+// registers a dbresolver plugin that sends SELECTs to the replicas
+// (picked at random per query) and writes to the primary.
 func NewDatabase(cfg config.DatabaseConfig) (*Database, error) {
-	// DSN compatible with pgx/postgres driver
-	dsn := fmt.Sprintf(
-		"host=%s port=%s user=%s password=%s dbname=%s sslmode=%s",
-		cfg.Host, cfg.Port, cfg.User, cfg.Password, cfg.Name, cfg.SSLMode,
-	)
+	drv, err := driverFor(cfg.Driver)
+	if err != nil {
+		return nil, err
+	}
 
 	// Configure logger (info level in dev, warn in prod)
 	logLevel := logger.Warn
@@ -46,25 +44,59 @@ func NewDatabase(cfg config.DatabaseConfig) (*Database, error) {
 		),
 	}
 
-	db, err := gorm.Open(postgres.Open(dsn), gormCfg)
+	db, err := gorm.Open(drv.Open(cfg), gormCfg)
 	if err != nil {
 		return nil, fmt.Errorf("failed to connect to database: %w", err)
 	}
 
-	// Configure connection pool
+	if len(cfg.ReadReplicas) > 0 {
+		replicas := make([]gorm.Dialector, len(cfg.ReadReplicas))
+		for i, dsn := range cfg.ReadReplicas {
+			replicas[i] = drv.OpenReplica(dsn)
+		}
+		resolverCfg := dbresolver.Config{Replicas: replicas, Policy: dbresolver.RandomPolicy{}}
+		if err := db.Use(
+			dbresolver.Register(resolverCfg).
+				SetMaxOpenConns(poolSetting(cfg.MaxOpenConns, 100)).
+				SetMaxIdleConns(poolSetting(cfg.MaxIdleConns, 10)).
+				SetConnMaxLifetime(poolDuration(cfg.ConnMaxLifetime, time.Hour)),
+		); err != nil {
+			return nil, fmt.Errorf("failed to register read replicas: %w", err)
+		}
+		log.Printf("Database read replicas registered: %d", len(cfg.ReadReplicas))
+	}
+
+	// Configure connection pool on the primary
 	sqlDB, err := db.DB()
 	if err != nil {
 		return nil, fmt.Errorf("failed to get sql DB from gorm: %w", err)
 	}
-	sqlDB.SetMaxIdleConns(10)
-	sqlDB.SetMaxOpenConns(100)
-	sqlDB.SetConnMaxLifetime(1 * time.Hour)
+	sqlDB.SetMaxIdleConns(poolSetting(cfg.MaxIdleConns, 10))
+	sqlDB.SetMaxOpenConns(poolSetting(cfg.MaxOpenConns, 100))
+	sqlDB.SetConnMaxLifetime(poolDuration(cfg.ConnMaxLifetime, time.Hour))
 
 	log.Println("Database connection established (GORM v2)")
 
 	return &Database{DB: db}, nil
 }
 
+// poolSetting falls back to a default when cfg leaves a pool knob unset
+// (zero-value), e.g. for callers that construct a DatabaseConfig by hand
+// rather than via config.New.
+func poolSetting(configured, fallback int) int {
+	if configured <= 0 {
+		return fallback
+	}
+	return configured
+}
+
+func poolDuration(configured, fallback time.Duration) time.Duration {
+	if configured <= 0 {
+		return fallback
+	}
+	return configured
+}
+
 // Close closes the database connection
 func (d *Database) Close() error {
 	sqlDB, err := d.DB.DB()
@@ -83,46 +115,6 @@ func (d *Database) Health() error {
 	return sqlDB.Ping()
 }
 
-// RunMigrations runs database migrations using golang-migrate
-func RunMigrations(cfg config.DatabaseConfig) error {
-	// SQL DSN for database/sql
-	dsn := fmt.Sprintf(
-		"postgres://%s:%s@%s:%s/%s?sslmode=%s",
-		cfg.User, cfg.Password, cfg.Host, cfg.Port, cfg.Name, cfg.SSLMode,
-	)
-
-	// Open database connection for migrations
-	db, err := sql.Open("postgres", dsn)
-	if err != nil {
-		return fmt.Errorf("failed to open database for migrations: %w", err)
-	}
-	defer db.Close()
-
-	// Create migration driver
-	driver, err := postgres.WithInstance(db, &postgres.Config{})
-	if err != nil {
-		return fmt.Errorf("failed to create migration driver: %w", err)
-	}
-
-	// Create migrator
-	m, err := migrate.NewWithDatabaseInstance(
-		"file://migrations",
-		"postgres",
-		driver,
-	)
-	if err != nil {
-		return fmt.Errorf("failed to create migrator: %w", err)
-	}
-
-	// Run migrations
-	if err := m.Up(); err != nil && err != migrate.ErrNoChange {
-		return fmt.Errorf("failed to run migrations: %w", err)
-	}
-
-	log.Println("Migrations completed successfully")
-	return nil
-}
-
 // AutoMigrate runs GORM auto-migrations for development
 func (d *Database) AutoMigrate(models ...interface{}) error {
 	return d.DB.AutoMigrate(models...)
@@ -0,0 +1,50 @@
+package storage
+
+import (
+	"database/sql"
+	"fmt"
+
+	"github.com/bartosz/stocks-out-for-harambe/backend/internal/config"
+	migratedb "github.com/golang-migrate/migrate/v4/database"
+	"gorm.io/gorm"
+)
+
+// Driver abstracts the GORM dialector and golang-migrate database driver
+// construction for one database backend, so NewDatabase and the migrator
+// always agree on which connection layer (pgx, mysql, sqlite) is in use.
+type Driver interface {
+	// Open returns the GORM dialector for the primary connection.
+	Open(cfg config.DatabaseConfig) gorm.Dialector
+	// OpenReplica returns the GORM dialector for one read-replica DSN.
+	OpenReplica(dsn string) gorm.Dialector
+	// MigrateDriverName is the golang-migrate database driver name this
+	// Driver registers itself under, e.g. "pgx5".
+	MigrateDriverName() string
+	// MigrateDB opens a *sql.DB against cfg using the stdlib driver this
+	// Driver's migrator needs (distinct from the one GORM uses itself).
+	MigrateDB(cfg config.DatabaseConfig) (*sql.DB, error)
+	// MigrateDriver wraps an already-open *sql.DB (from MigrateDB) in the
+	// matching golang-migrate database.Driver.
+	MigrateDriver(db *sql.DB) (migratedb.Driver, error)
+}
+
+// driverFor resolves a config.DatabaseConfig.Driver name to a Driver.
+// Unset resolves to postgres, this repo's original and only backend with
+// a real migration (migrations/0001_init.up.sql uses JSONB and enum
+// types that are Postgres-specific) — mysql and sqlite are wired up at
+// the connection layer for callers that supply their own schema (e.g.
+// sqlite-backed unit tests via AutoMigrate). newMigrator rejects them
+// outright rather than letting RunMigrations apply postgres-only SQL to
+// them, until mysql/sqlite equivalents of 0001_init exist.
+func driverFor(name string) (Driver, error) {
+	switch name {
+	case "", "postgres":
+		return postgresDriver{}, nil
+	case "mysql":
+		return mysqlDriver{}, nil
+	case "sqlite":
+		return sqliteDriver{}, nil
+	default:
+		return nil, fmt.Errorf("unknown database driver %q", name)
+	}
+}
@@ -0,0 +1,43 @@
+package storage
+
+import (
+	"database/sql"
+	"fmt"
+
+	"github.com/bartosz/stocks-out-for-harambe/backend/internal/config"
+	_ "github.com/go-sql-driver/mysql" // registers the "mysql" database/sql driver
+	migratedb "github.com/golang-migrate/migrate/v4/database"
+	migratemysql "github.com/golang-migrate/migrate/v4/database/mysql"
+	gmysql "gorm.io/driver/mysql"
+	"gorm.io/gorm"
+)
+
+// mysqlDriver is a GORM/golang-migrate pairing for MySQL. It has no
+// matching SQL migration yet (see driverFor's doc comment) and is meant
+// for code that supplies its own schema.
+type mysqlDriver struct{}
+
+func (mysqlDriver) Open(cfg config.DatabaseConfig) gorm.Dialector {
+	return gmysql.New(gmysql.Config{DSN: mysqlDSN(cfg)})
+}
+
+func (mysqlDriver) OpenReplica(dsn string) gorm.Dialector {
+	return gmysql.New(gmysql.Config{DSN: dsn})
+}
+
+func (mysqlDriver) MigrateDriverName() string { return "mysql" }
+
+func (mysqlDriver) MigrateDB(cfg config.DatabaseConfig) (*sql.DB, error) {
+	return sql.Open("mysql", mysqlDSN(cfg))
+}
+
+func (mysqlDriver) MigrateDriver(db *sql.DB) (migratedb.Driver, error) {
+	return migratemysql.WithInstance(db, &migratemysql.Config{})
+}
+
+func mysqlDSN(cfg config.DatabaseConfig) string {
+	return fmt.Sprintf(
+		"%s:%s@tcp(%s:%s)/%s?parseTime=true&multiStatements=true",
+		cfg.User, cfg.Password, cfg.Host, cfg.Port, cfg.Name,
+	)
+}
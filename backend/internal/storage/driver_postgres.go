@@ -0,0 +1,44 @@
+package storage
+
+import (
+	"database/sql"
+	"fmt"
+
+	"github.com/bartosz/stocks-out-for-harambe/backend/internal/config"
+	migratedb "github.com/golang-migrate/migrate/v4/database"
+	migratepgx "github.com/golang-migrate/migrate/v4/database/pgx/v5"
+	_ "github.com/jackc/pgx/v5/stdlib" // registers the "pgx" database/sql driver
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+// postgresDriver is the default Driver. gorm.io/driver/postgres speaks
+// pgx/v5 natively, so no separate DSN handling is needed for GORM itself;
+// only the migrator needs its own *sql.DB, opened through the pgx stdlib
+// driver instead of the lib/pq one this package used before.
+type postgresDriver struct{}
+
+func (postgresDriver) Open(cfg config.DatabaseConfig) gorm.Dialector {
+	return postgres.New(postgres.Config{DSN: postgresDSN(cfg)})
+}
+
+func (postgresDriver) OpenReplica(dsn string) gorm.Dialector {
+	return postgres.New(postgres.Config{DSN: dsn})
+}
+
+func (postgresDriver) MigrateDriverName() string { return "pgx5" }
+
+func (postgresDriver) MigrateDB(cfg config.DatabaseConfig) (*sql.DB, error) {
+	return sql.Open("pgx", postgresDSN(cfg))
+}
+
+func (postgresDriver) MigrateDriver(db *sql.DB) (migratedb.Driver, error) {
+	return migratepgx.WithInstance(db, &migratepgx.Config{})
+}
+
+func postgresDSN(cfg config.DatabaseConfig) string {
+	return fmt.Sprintf(
+		"host=%s port=%s user=%s password=%s dbname=%s sslmode=%s",
+		cfg.Host, cfg.Port, cfg.User, cfg.Password, cfg.Name, cfg.SSLMode,
+	)
+}
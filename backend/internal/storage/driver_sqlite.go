@@ -0,0 +1,37 @@
+package storage
+
+import (
+	"database/sql"
+
+	"github.com/bartosz/stocks-out-for-harambe/backend/internal/config"
+	"github.com/glebarez/sqlite" // pure-Go GORM dialector, no CGO required
+	migratedb "github.com/golang-migrate/migrate/v4/database"
+	migratesqlite "github.com/golang-migrate/migrate/v4/database/sqlite"
+	"gorm.io/gorm"
+	_ "modernc.org/sqlite" // registers the "sqlite" database/sql driver
+)
+
+// sqliteDriver exists for tests: config.DatabaseConfig.Name doubles as the
+// sqlite DSN (a file path, or ":memory:"), and no network round trip is
+// needed. It has no matching SQL migration yet (see driverFor's doc
+// comment); tests that want sqlite should call Database.AutoMigrate
+// instead of storage.RunMigrations.
+type sqliteDriver struct{}
+
+func (sqliteDriver) Open(cfg config.DatabaseConfig) gorm.Dialector {
+	return sqlite.Open(cfg.Name)
+}
+
+func (sqliteDriver) OpenReplica(dsn string) gorm.Dialector {
+	return sqlite.Open(dsn)
+}
+
+func (sqliteDriver) MigrateDriverName() string { return "sqlite" }
+
+func (sqliteDriver) MigrateDB(cfg config.DatabaseConfig) (*sql.DB, error) {
+	return sql.Open("sqlite", cfg.Name)
+}
+
+func (sqliteDriver) MigrateDriver(db *sql.DB) (migratedb.Driver, error) {
+	return migratesqlite.WithInstance(db, &migratesqlite.Config{})
+}
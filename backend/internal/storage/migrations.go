@@ -0,0 +1,154 @@
+package storage
+
+import (
+	"embed"
+	"errors"
+	"fmt"
+	"log"
+
+	"github.com/bartosz/stocks-out-for-harambe/backend/internal/config"
+	"github.com/golang-migrate/migrate/v4"
+	"github.com/golang-migrate/migrate/v4/source/iofs"
+)
+
+// migrationFS embeds every migration SQL file into the binary, so a
+// deployed build needs no on-disk migrations directory (the prior
+// file://migrations source required one to exist next to the working
+// directory, which this repo never actually shipped).
+//
+//go:embed migrations/*.sql
+var migrationFS embed.FS
+
+// newMigrator opens a fresh *sql.DB via cfg.Driver's own stdlib driver and
+// wraps it in a golang-migrate Migrate instance backed by migrationFS, so
+// the migrator always talks to the database through the same connection
+// layer as the matching storage.Driver. Callers should arrange to close
+// the returned Migrate (via its Close method) when done.
+//
+// Only postgres has a real migration (0001_init.up.sql uses JSONB/enum
+// types specific to it): mysql and sqlite are wired up at the connection
+// layer (storage.Driver.Open) for callers that supply their own schema,
+// e.g. sqlite-backed unit tests via Database.AutoMigrate, but none of the
+// migrate.Migrate operations below know how to apply postgres-only SQL to
+// them, so this rejects anything but postgres up front instead of letting
+// Up/Down run partway and fail mid-migration.
+func newMigrator(cfg config.DatabaseConfig) (*migrate.Migrate, error) {
+	if cfg.Driver != "" && cfg.Driver != "postgres" {
+		return nil, fmt.Errorf("storage: RunMigrations/MigrateDown/MigrateTo only support the postgres driver; %q has no matching SQL migration (see Database.AutoMigrate for driver-agnostic schema setup)", cfg.Driver)
+	}
+
+	drv, err := driverFor(cfg.Driver)
+	if err != nil {
+		return nil, err
+	}
+
+	db, err := drv.MigrateDB(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open database for migrations: %w", err)
+	}
+
+	migrateDriver, err := drv.MigrateDriver(db)
+	if err != nil {
+		db.Close()
+		return nil, fmt.Errorf("failed to create migration driver: %w", err)
+	}
+
+	source, err := iofs.New(migrationFS, "migrations")
+	if err != nil {
+		db.Close()
+		return nil, fmt.Errorf("failed to open embedded migration source: %w", err)
+	}
+
+	m, err := migrate.NewWithInstance("iofs", source, drv.MigrateDriverName(), migrateDriver)
+	if err != nil {
+		db.Close()
+		return nil, fmt.Errorf("failed to create migrator: %w", err)
+	}
+
+	return m, nil
+}
+
+// RunMigrations applies every pending migration embedded in the binary.
+func RunMigrations(cfg config.DatabaseConfig) error {
+	m, err := newMigrator(cfg)
+	if err != nil {
+		return err
+	}
+	defer m.Close()
+
+	if err := m.Up(); err != nil && !errors.Is(err, migrate.ErrNoChange) {
+		return fmt.Errorf("failed to run migrations: %w", err)
+	}
+
+	log.Println("Migrations completed successfully")
+	return nil
+}
+
+// MigrateDown rolls back the given number of migration steps. steps must
+// be positive; golang-migrate's own Steps takes the sign as direction.
+func MigrateDown(cfg config.DatabaseConfig, steps int) error {
+	if steps <= 0 {
+		return fmt.Errorf("steps must be positive, got %d", steps)
+	}
+
+	m, err := newMigrator(cfg)
+	if err != nil {
+		return err
+	}
+	defer m.Close()
+
+	if err := m.Steps(-steps); err != nil && !errors.Is(err, migrate.ErrNoChange) {
+		return fmt.Errorf("failed to roll back %d step(s): %w", steps, err)
+	}
+	return nil
+}
+
+// MigrateTo migrates up or down to pin the schema at exactly version.
+func MigrateTo(cfg config.DatabaseConfig, version uint) error {
+	m, err := newMigrator(cfg)
+	if err != nil {
+		return err
+	}
+	defer m.Close()
+
+	if err := m.Migrate(version); err != nil && !errors.Is(err, migrate.ErrNoChange) {
+		return fmt.Errorf("failed to migrate to version %d: %w", version, err)
+	}
+	return nil
+}
+
+// MigrateVersion reports the schema's current migration version and
+// whether it's left dirty by a previously failed migration.
+func MigrateVersion(cfg config.DatabaseConfig) (version uint, dirty bool, err error) {
+	m, err := newMigrator(cfg)
+	if err != nil {
+		return 0, false, err
+	}
+	defer m.Close()
+
+	version, dirty, err = m.Version()
+	if errors.Is(err, migrate.ErrNilVersion) {
+		return 0, false, nil
+	}
+	if err != nil {
+		return 0, false, fmt.Errorf("failed to read migration version: %w", err)
+	}
+	return version, dirty, nil
+}
+
+// MigrateForce sets the schema_migrations version without running any
+// migration, clearing the dirty flag left by a failed migration so Up/Down
+// can proceed again. version is the last version known to have actually
+// applied cleanly.
+func MigrateForce(cfg config.DatabaseConfig, version int) error {
+	m, err := newMigrator(cfg)
+	if err != nil {
+		return err
+	}
+	defer m.Close()
+
+	if err := m.Force(version); err != nil {
+		return fmt.Errorf("failed to force migration version %d: %w", version, err)
+	}
+	return nil
+}
@@ -0,0 +1,144 @@
+// Package testsupport provides a Testcontainers-backed PostgreSQL harness
+// for integration tests against the storage package and Gin handlers. It
+// boots a single ephemeral postgres container per test binary run, applies
+// the project's embedded migrations (see storage.RunMigrations) once, and
+// lets individual tests get a clean slate via WithCleanDB instead of paying
+// container startup cost per test.
+package testsupport
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/bartosz/stocks-out-for-harambe/backend/internal/config"
+	"github.com/bartosz/stocks-out-for-harambe/backend/internal/storage"
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/modules/postgres"
+	"github.com/testcontainers/testcontainers-go/wait"
+)
+
+// tables lists every table created by the embedded migrations, matching
+// migrations/0001_init.down.sql's drop order.
+var tables = []string{
+	"audit_events",
+	"filters",
+	"sitemap_discoveries",
+	"extraction_checkpoints",
+	"extraction_retries",
+	"url_extractions",
+	"extraction_sessions",
+	"keyword_doc_freqs",
+	"translations",
+	"entities",
+	"keywords",
+	"text_analyses",
+	"chart_data_points",
+	"datasets",
+}
+
+// StartDatabase starts a fresh, ephemeral postgres container, waits for it
+// to accept connections, runs the project's migrations against it, and
+// returns a fully-wired *storage.Database plus a teardown func that
+// terminates the container. Most tests should prefer WithCleanDB, which
+// reuses a single container across the whole test binary instead of
+// paying container startup cost per test.
+func StartDatabase(ctx context.Context) (*storage.Database, func(), error) {
+	container, err := postgres.RunContainer(ctx,
+		postgres.WithDatabase("symbiot_test"),
+		postgres.WithUsername("symbiot"),
+		postgres.WithPassword("symbiot"),
+		testcontainers.WithWaitStrategy(
+			wait.ForLog("database system is ready to accept connections").
+				WithOccurrence(2).
+				WithStartupTimeout(60*time.Second),
+		),
+	)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to start postgres container: %w", err)
+	}
+
+	teardown := func() {
+		if err := container.Terminate(ctx); err != nil {
+			log.Printf("testsupport: failed to terminate postgres container: %v", err)
+		}
+	}
+
+	host, err := container.Host(ctx)
+	if err != nil {
+		teardown()
+		return nil, nil, fmt.Errorf("failed to read container host: %w", err)
+	}
+	mappedPort, err := container.MappedPort(ctx, "5432/tcp")
+	if err != nil {
+		teardown()
+		return nil, nil, fmt.Errorf("failed to read mapped port: %w", err)
+	}
+
+	cfg := config.DatabaseConfig{
+		Host:     host,
+		Port:     mappedPort.Port(),
+		User:     "symbiot",
+		Password: "symbiot",
+		Name:     "symbiot_test",
+		SSLMode:  "disable",
+	}
+
+	if err := storage.RunMigrations(cfg); err != nil {
+		teardown()
+		return nil, nil, fmt.Errorf("failed to run migrations: %w", err)
+	}
+
+	db, err := storage.NewDatabase(cfg)
+	if err != nil {
+		teardown()
+		return nil, nil, fmt.Errorf("failed to connect to test database: %w", err)
+	}
+
+	return db, func() {
+		db.Close()
+		teardown()
+	}, nil
+}
+
+var (
+	sharedOnce sync.Once
+	sharedDB   *storage.Database
+	sharedErr  error
+)
+
+// sharedDatabase lazily starts the single container shared by WithCleanDB
+// across the whole test binary run, so a suite with many tests pays the
+// container startup cost once instead of per test. The container is left
+// running for the life of the process; testcontainers' own reaper cleans
+// it up once the test binary exits.
+func sharedDatabase(tb testing.TB) *storage.Database {
+	tb.Helper()
+
+	sharedOnce.Do(func() {
+		sharedDB, _, sharedErr = StartDatabase(context.Background())
+	})
+	if sharedErr != nil {
+		tb.Fatalf("testsupport: failed to start shared postgres container: %v", sharedErr)
+	}
+	return sharedDB
+}
+
+// WithCleanDB runs fn against the shared test database, truncating every
+// table first so a test never sees rows left behind by an earlier one.
+func WithCleanDB(t *testing.T, fn func(*storage.Database)) {
+	t.Helper()
+
+	db := sharedDatabase(t)
+
+	stmt := fmt.Sprintf("TRUNCATE TABLE %s RESTART IDENTITY CASCADE", strings.Join(tables, ", "))
+	if err := db.Exec(stmt).Error; err != nil {
+		t.Fatalf("testsupport: failed to truncate tables: %v", err)
+	}
+
+	fn(db)
+}
@@ -0,0 +1,142 @@
+package extract
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+)
+
+var (
+	emailPattern = regexp.MustCompile(`\b[\w.+-]+@[\w-]+\.[\w.-]+\b`)
+	urlPattern   = regexp.MustCompile(`\bhttps?://[^\s]+`)
+	moneyPattern = regexp.MustCompile(`\$\d[\d,]*(?:\.\d{1,2})?\b|\b\d[\d,]*(?:\.\d{1,2})?\s?(?:USD|EUR|GBP|dollars|euros)\b`)
+	datePattern  = regexp.MustCompile(`\b\d{4}-\d{2}-\d{2}\b` +
+		`|\b(?:January|February|March|April|May|June|July|August|September|October|November|December)\s+\d{1,2},?\s+\d{4}\b` +
+		`|\b\d{1,2}/\d{1,2}/\d{2,4}\b`)
+
+	orgSuffixPattern = regexp.MustCompile(`\b[A-Z][a-zA-Z&]*(?:\s[A-Z][a-zA-Z&]*)*\s(?:Inc|LLC|Corp|Company|Corporation|Ltd)\.?\b`)
+	personPattern    = regexp.MustCompile(`\b[A-Z][a-z]+(?:\s[A-Z][a-z]+)+\b`)
+
+	sentenceTerminator = regexp.MustCompile(`[.!?]+\s+`)
+)
+
+// gazetteer is a small bundled list of countries and major cities, matched
+// as whole phrases. It's intentionally short: locations outside it still
+// have a chance of being picked up by the weaker capitalization heuristic
+// (as "person", since this package doesn't try to distinguish further).
+var gazetteer = []string{
+	"United States", "United Kingdom", "Canada", "Mexico", "France", "Germany",
+	"Italy", "Spain", "China", "Japan", "India", "Brazil", "Australia", "Russia",
+	"New York", "Los Angeles", "Chicago", "Houston", "Phoenix", "Philadelphia",
+	"San Antonio", "San Diego", "Dallas", "San Jose", "Austin", "Jacksonville",
+	"Fort Worth", "Columbus", "Charlotte", "San Francisco", "Indianapolis",
+	"Seattle", "Denver", "Washington", "Boston", "El Paso", "Detroit",
+	"Nashville", "Portland", "Memphis", "Oklahoma City", "Las Vegas",
+	"Louisville", "Baltimore", "Milwaukee", "Albuquerque", "Tucson", "Fresno",
+	"Sacramento", "Mesa", "Kansas City", "Atlanta", "Long Beach",
+	"Colorado Springs", "Raleigh", "Miami", "Virginia Beach", "Omaha",
+	"Oakland", "Minneapolis", "Tulsa", "Arlington", "Tampa", "New Orleans",
+	"London", "Paris", "Berlin", "Tokyo", "Beijing", "Moscow", "Toronto",
+}
+
+var gazetteerPattern = buildGazetteerPattern(gazetteer)
+
+// buildGazetteerPattern compiles names into one alternation, longest first
+// so a multi-word name like "New York" matches before a hypothetical
+// single-word prefix would.
+func buildGazetteerPattern(names []string) *regexp.Regexp {
+	sorted := append([]string(nil), names...)
+	sort.Slice(sorted, func(i, j int) bool { return len(sorted[i]) > len(sorted[j]) })
+	escaped := make([]string, len(sorted))
+	for i, name := range sorted {
+		escaped[i] = regexp.QuoteMeta(name)
+	}
+	return regexp.MustCompile(`\b(?:` + strings.Join(escaped, "|") + `)\b`)
+}
+
+// span is a half-open byte range [start, end) already claimed by a
+// higher-confidence match, so weaker rules don't re-match the same text.
+type span struct{ start, end int }
+
+type claimSet struct{ spans []span }
+
+func (c *claimSet) add(start, end int) { c.spans = append(c.spans, span{start, end}) }
+
+func (c *claimSet) overlaps(start, end int) bool {
+	for _, s := range c.spans {
+		if start < s.end && end > s.start {
+			return true
+		}
+	}
+	return false
+}
+
+// Entities extracts named entities from text with a cascade of rules, run
+// in this order: regexes for email and url (0.9), then money and date
+// (0.9), a gazetteer lookup (location, 0.95), a company-suffix regex
+// (organization, 0.9), and a capitalization heuristic for multi-word names
+// not at the start of a sentence (person, 0.6), since a capitalized
+// sentence-initial word carries no entity signal on its own. Each rule
+// after url skips spans an earlier rule already claimed; the email and url
+// regexes do not check each other's spans.
+func Entities(text string) []Entity {
+	var entities []Entity
+	claimed := &claimSet{}
+
+	add := func(start, end int, entityType string, confidence float64) {
+		entities = append(entities, Entity{
+			Text:       text[start:end],
+			Type:       entityType,
+			Confidence: confidence,
+			StartPos:   start,
+			EndPos:     end,
+		})
+		claimed.add(start, end)
+	}
+
+	for _, loc := range emailPattern.FindAllStringIndex(text, -1) {
+		add(loc[0], loc[1], "email", 0.9)
+	}
+	for _, loc := range urlPattern.FindAllStringIndex(text, -1) {
+		add(loc[0], loc[1], "url", 0.9)
+	}
+	for _, loc := range moneyPattern.FindAllStringIndex(text, -1) {
+		if claimed.overlaps(loc[0], loc[1]) {
+			continue
+		}
+		add(loc[0], loc[1], "money", 0.9)
+	}
+	for _, loc := range datePattern.FindAllStringIndex(text, -1) {
+		if claimed.overlaps(loc[0], loc[1]) {
+			continue
+		}
+		add(loc[0], loc[1], "date", 0.9)
+	}
+	for _, loc := range gazetteerPattern.FindAllStringIndex(text, -1) {
+		if claimed.overlaps(loc[0], loc[1]) {
+			continue
+		}
+		add(loc[0], loc[1], "location", 0.95)
+	}
+	for _, loc := range orgSuffixPattern.FindAllStringIndex(text, -1) {
+		if claimed.overlaps(loc[0], loc[1]) {
+			continue
+		}
+		add(loc[0], loc[1], "organization", 0.9)
+	}
+
+	sentenceStarts := map[int]bool{0: true}
+	for _, loc := range sentenceTerminator.FindAllStringIndex(text, -1) {
+		sentenceStarts[loc[1]] = true
+	}
+
+	for _, loc := range personPattern.FindAllStringIndex(text, -1) {
+		if claimed.overlaps(loc[0], loc[1]) || sentenceStarts[loc[0]] {
+			continue
+		}
+		add(loc[0], loc[1], "person", 0.6)
+	}
+
+	sort.Slice(entities, func(i, j int) bool { return entities[i].StartPos < entities[j].StartPos })
+	return entities
+}
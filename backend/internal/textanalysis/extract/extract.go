@@ -0,0 +1,24 @@
+// Package extract implements in-process keyword and named-entity extraction
+// for TextAnalysis, so Keyword/Entity rows are derived from the text itself
+// rather than left for callers to populate.
+package extract
+
+// Keyword is one candidate keyword or keyphrase found in a document.
+type Keyword struct {
+	Word      string
+	Frequency int
+	// Relevance is the RAKE phrase score divided by the highest phrase score
+	// in the same document, so the top-ranked keyword has Relevance 1.
+	// Keywords does not compute TF-IDF weights; TFIDF does that separately.
+	Relevance float64
+}
+
+// Entity is one named entity found in a document, with byte offsets into
+// the original text.
+type Entity struct {
+	Text       string
+	Type       string // person, organization, location, money, date, url, email
+	Confidence float64
+	StartPos   int
+	EndPos     int
+}
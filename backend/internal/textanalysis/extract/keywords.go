@@ -0,0 +1,219 @@
+package extract
+
+import (
+	"math"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// DefaultStopwords returns the stopword list RAKE splits candidate phrases
+// on when KeywordOptions.Stopwords is nil.
+func DefaultStopwords() map[string]bool {
+	return map[string]bool{
+		"the": true, "a": true, "an": true, "and": true, "or": true, "but": true,
+		"in": true, "on": true, "at": true, "to": true, "for": true, "of": true, "with": true,
+		"by": true, "from": true, "up": true, "about": true, "into": true, "through": true,
+		"during": true, "before": true, "after": true, "above": true, "below": true,
+		"is": true, "are": true, "was": true, "were": true, "be": true, "been": true, "being": true,
+		"have": true, "has": true, "had": true, "do": true, "does": true, "did": true,
+		"this": true, "that": true, "these": true, "those": true, "i": true, "you": true,
+		"he": true, "she": true, "it": true, "we": true, "they": true, "as": true, "can": true,
+		"will": true, "would": true, "could": true, "should": true, "not": true, "no": true,
+	}
+}
+
+// KeywordOptions configures Keywords.
+type KeywordOptions struct {
+	// Stopwords defaults to DefaultStopwords() when nil.
+	Stopwords map[string]bool
+	// TopN defaults to 10.
+	TopN int
+}
+
+// Keywords extracts candidate keyphrases from text using RAKE
+// (Rapid Automatic Keyword Extraction): text is split into candidate
+// phrases on sentence delimiters and stopwords, each word is scored as
+// deg(w)/freq(w) where deg is the sum of co-occurrence counts within
+// candidate phrases (including the word itself) and freq is its total
+// occurrence count, and each phrase's score is the sum of its member
+// words' scores. Relevance is the phrase score normalized against the
+// top phrase in this document; Frequency is the phrase's raw occurrence
+// count.
+func Keywords(text string, opts KeywordOptions) []Keyword {
+	stopwords := opts.Stopwords
+	if stopwords == nil {
+		stopwords = DefaultStopwords()
+	}
+	topN := opts.TopN
+	if topN <= 0 {
+		topN = 10
+	}
+
+	phrases := candidatePhrases(text, stopwords)
+	if len(phrases) == 0 {
+		return nil
+	}
+
+	wordFreq := map[string]int{}
+	wordDeg := map[string]int{}
+	for _, phrase := range phrases {
+		coOccurrence := len(phrase) - 1
+		for _, w := range phrase {
+			wordFreq[w]++
+			wordDeg[w] += coOccurrence + 1 // +1 so a word's own occurrence counts toward its degree
+		}
+	}
+
+	wordScore := make(map[string]float64, len(wordFreq))
+	for w, freq := range wordFreq {
+		wordScore[w] = float64(wordDeg[w]) / float64(freq)
+	}
+
+	type ranked struct {
+		phrase string
+		words  []string
+		score  float64
+		freq   int
+	}
+	byPhrase := map[string]*ranked{}
+	var order []string
+	for _, phrase := range phrases {
+		key := strings.Join(phrase, " ")
+		r, ok := byPhrase[key]
+		if !ok {
+			var score float64
+			for _, w := range phrase {
+				score += wordScore[w]
+			}
+			r = &ranked{phrase: key, words: phrase, score: score}
+			byPhrase[key] = r
+			order = append(order, key)
+		}
+		r.freq++
+	}
+
+	results := make([]*ranked, 0, len(order))
+	for _, key := range order {
+		results = append(results, byPhrase[key])
+	}
+	sort.Slice(results, func(i, j int) bool {
+		if results[i].score != results[j].score {
+			return results[i].score > results[j].score
+		}
+		return results[i].phrase < results[j].phrase // deterministic tie-break
+	})
+
+	if len(results) > topN {
+		results = results[:topN]
+	}
+
+	maxScore := 0.0
+	for _, r := range results {
+		if r.score > maxScore {
+			maxScore = r.score
+		}
+	}
+
+	keywords := make([]Keyword, len(results))
+	for i, r := range results {
+		relevance := 0.0
+		if maxScore > 0 {
+			relevance = r.score / maxScore
+		}
+		keywords[i] = Keyword{Word: r.phrase, Frequency: r.freq, Relevance: relevance}
+	}
+	return keywords
+}
+
+// candidatePhrases splits text on sentence delimiters and stopwords,
+// returning the runs of consecutive non-stopword content words. This is
+// RAKE's standard candidate-generation step.
+func candidatePhrases(text string, stopwords map[string]bool) [][]string {
+	var phrases [][]string
+	var current []string
+
+	flushPhrase := func() {
+		if len(current) > 0 {
+			phrases = append(phrases, current)
+			current = nil
+		}
+	}
+
+	var token strings.Builder
+	flushToken := func() {
+		if token.Len() == 0 {
+			return
+		}
+		word := token.String()
+		token.Reset()
+		if stopwords[word] {
+			flushPhrase()
+		} else {
+			current = append(current, word)
+		}
+	}
+
+	for _, r := range text {
+		switch {
+		case unicode.IsLetter(r) || unicode.IsDigit(r) || r == '\'':
+			token.WriteRune(unicode.ToLower(r))
+		case strings.ContainsRune(".,!?;:()\"\n", r):
+			flushToken()
+			flushPhrase()
+		default:
+			flushToken()
+		}
+	}
+	flushToken()
+	flushPhrase()
+
+	return phrases
+}
+
+// WordFrequencies tokenizes text into content words (stopwords excluded by
+// the same rules as Keywords) and counts their occurrences, for use as
+// termFreq in TFIDF.
+func WordFrequencies(text string, stopwords map[string]bool) map[string]int {
+	if stopwords == nil {
+		stopwords = DefaultStopwords()
+	}
+	freq := map[string]int{}
+	for _, phrase := range candidatePhrases(text, stopwords) {
+		for _, w := range phrase {
+			freq[w]++
+		}
+	}
+	return freq
+}
+
+// TFIDF computes a TF-IDF weight for every word in termFreq (this
+// document's word counts), given docFreq (corpus-wide document counts per
+// word, from models.KeywordDocFreq) and totalDocs (corpus size, including
+// this document). A word absent from docFreq is treated as appearing in
+// only this one document, which yields the maximum possible IDF for it.
+func TFIDF(termFreq map[string]int, docFreq map[string]int, totalDocs int) map[string]float64 {
+	scores := make(map[string]float64, len(termFreq))
+
+	var totalTerms int
+	for _, c := range termFreq {
+		totalTerms += c
+	}
+	if totalTerms == 0 {
+		return scores
+	}
+	if totalDocs < 1 {
+		totalDocs = 1
+	}
+
+	for word, freq := range termFreq {
+		tf := float64(freq) / float64(totalTerms)
+		df := docFreq[word]
+		if df < 1 {
+			df = 1
+		}
+		idf := math.Log(float64(totalDocs)/float64(df)) + 1
+		scores[word] = tf * idf
+	}
+	return scores
+}
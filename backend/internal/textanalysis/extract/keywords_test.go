@@ -0,0 +1,98 @@
+package extract
+
+import (
+	"math"
+	"testing"
+)
+
+func TestKeywords_ReturnsTopPhrase(t *testing.T) {
+	text := "Machine learning algorithms. Machine learning models are powerful. " +
+		"Learning algorithms improve over time."
+	keywords := Keywords(text, KeywordOptions{TopN: 5})
+
+	if len(keywords) == 0 {
+		t.Fatal("expected at least one keyword")
+	}
+	if keywords[0].Relevance != 1 {
+		t.Errorf("expected the top keyword's Relevance to be normalized to 1, got %v", keywords[0].Relevance)
+	}
+
+	found := false
+	for _, k := range keywords {
+		if k.Word == "machine learning algorithms" || k.Word == "machine learning models" {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("expected a machine-learning phrase among keywords, got %+v", keywords)
+	}
+}
+
+func TestKeywords_EmptyText(t *testing.T) {
+	if got := Keywords("", KeywordOptions{}); got != nil {
+		t.Errorf("expected nil keywords for empty text, got %+v", got)
+	}
+}
+
+func TestKeywords_RespectsTopN(t *testing.T) {
+	text := "alpha beta. gamma delta. epsilon zeta. eta theta. iota kappa."
+	keywords := Keywords(text, KeywordOptions{TopN: 2})
+	if len(keywords) != 2 {
+		t.Fatalf("expected TopN to cap results at 2, got %d", len(keywords))
+	}
+}
+
+func TestCandidatePhrases_SplitsOnStopwordsAndPunctuation(t *testing.T) {
+	phrases := candidatePhrases("the quick brown fox, and the lazy dog", DefaultStopwords())
+	want := [][]string{{"quick", "brown", "fox"}, {"lazy", "dog"}}
+	if len(phrases) != len(want) {
+		t.Fatalf("got %v, want %v", phrases, want)
+	}
+	for i := range want {
+		if len(phrases[i]) != len(want[i]) {
+			t.Errorf("phrase %d = %v, want %v", i, phrases[i], want[i])
+			continue
+		}
+		for j := range want[i] {
+			if phrases[i][j] != want[i][j] {
+				t.Errorf("phrase %d word %d = %q, want %q", i, j, phrases[i][j], want[i][j])
+			}
+		}
+	}
+}
+
+func TestWordFrequencies_CountsContentWordsOnly(t *testing.T) {
+	freq := WordFrequencies("the cat and the dog and the cat", nil)
+	if freq["cat"] != 2 {
+		t.Errorf("expected \"cat\" count 2, got %d", freq["cat"])
+	}
+	if freq["the"] != 0 {
+		t.Errorf("expected stopword \"the\" to be excluded, got %d", freq["the"])
+	}
+}
+
+func TestTFIDF_RareTermScoresHigherThanCommonTerm(t *testing.T) {
+	termFreq := map[string]int{"common": 5, "rare": 5}
+	docFreq := map[string]int{"common": 100, "rare": 1}
+
+	scores := TFIDF(termFreq, docFreq, 100)
+	if scores["rare"] <= scores["common"] {
+		t.Errorf("expected rare term to score higher than common term, got rare=%v common=%v", scores["rare"], scores["common"])
+	}
+}
+
+func TestTFIDF_EmptyTermFreq(t *testing.T) {
+	scores := TFIDF(map[string]int{}, map[string]int{}, 10)
+	if len(scores) != 0 {
+		t.Errorf("expected no scores for empty termFreq, got %+v", scores)
+	}
+}
+
+func TestTFIDF_UnseenWordGetsMaxIDF(t *testing.T) {
+	scores := TFIDF(map[string]int{"novel": 1}, map[string]int{}, 50)
+	// docFreq defaults to 1 for an unseen word, so idf = log(50/1) + 1.
+	want := math.Log(50) + 1
+	if diff := scores["novel"] - want; diff > 1e-9 || diff < -1e-9 {
+		t.Errorf("TFIDF for unseen word = %v, want %v", scores["novel"], want)
+	}
+}
@@ -0,0 +1,272 @@
+// Package textanalysis implements tokenization and the classical
+// readability formulas (Flesch, Flesch-Kincaid, Gunning Fog, SMOG, ARI,
+// Coleman-Liau) in a single pass over a text, so callers don't each
+// re-tokenize with their own ad-hoc regexes.
+package textanalysis
+
+import (
+	"math"
+	"strings"
+	"unicode"
+)
+
+// commonAbbreviations are sentence-terminator lookalikes the sentence
+// tokenizer must not split on, e.g. "Mr. Smith" is one sentence, not two.
+var commonAbbreviations = map[string]bool{
+	"mr": true, "mrs": true, "ms": true, "dr": true, "prof": true,
+	"sr": true, "jr": true, "st": true, "vs": true, "etc": true,
+	"e.g": true, "i.e": true, "inc": true, "ltd": true, "co": true,
+}
+
+// complexSuffixes are excluded from the Gunning Fog "complex word" count
+// even when the word has >= 3 syllables, per the original formula's
+// exemption for common inflections.
+var complexSuffixes = []string{"es", "ed", "ing"}
+
+// Result holds every statistic and readability score computed from a
+// single tokenization pass.
+type Result struct {
+	WordCount           int
+	CharacterCount      int
+	SentenceCount       int
+	SyllableCount       int
+	ComplexWordCount    int // Gunning Fog's "complex" words: >=3 syllables, not a proper noun or common suffix
+	PolysyllableCount   int // SMOG's words with >=3 syllables
+
+	AvgWordsPerSentence float64
+	AvgSyllablesPerWord float64
+
+	FleschReadingEase  float64
+	FleschKincaidGrade float64
+	GunningFog         float64
+	SMOG               float64
+	ARI                float64
+	ColemanLiau        float64
+
+	Level string // Flesch score band: "Very Easy" .. "Very Difficult"
+
+	// Supported is false when lang isn't English, since every formula here
+	// assumes English syllable/sentence conventions.
+	Supported bool
+}
+
+// Metrics tokenizes text and computes all readability statistics. For any
+// lang other than "en" (or empty, which defaults to English), it still
+// returns word/sentence/character counts but leaves Supported false and
+// the readability scores at zero, since the formulas don't transfer to
+// other languages' orthography.
+func Metrics(text string, lang string) Result {
+	sentences := splitSentences(text)
+	rawWords := tokenizeWordsCased(text)
+
+	m := Result{
+		CharacterCount: len([]rune(text)),
+		SentenceCount:  len(sentences),
+		WordCount:      len(rawWords),
+	}
+
+	english := lang == "" || lang == "en"
+	m.Supported = english
+	if !english || m.WordCount == 0 || m.SentenceCount == 0 {
+		return m
+	}
+
+	for i, w := range rawWords {
+		lower := strings.ToLower(w)
+		syllables := countSyllables(lower)
+		m.SyllableCount += syllables
+		if syllables >= 3 {
+			m.PolysyllableCount++
+			// A capitalized word that isn't the very first word of the text
+			// is treated as a proper noun and exempted from Gunning Fog's
+			// complex-word count; the first word is always excluded from
+			// this check since sentence-initial capitalization isn't evidence
+			// of anything.
+			if !(i > 0 && isProperNoun(w)) && !hasComplexSuffixException(lower) {
+				m.ComplexWordCount++
+			}
+		}
+	}
+
+	m.AvgWordsPerSentence = float64(m.WordCount) / float64(m.SentenceCount)
+	m.AvgSyllablesPerWord = float64(m.SyllableCount) / float64(m.WordCount)
+
+	m.FleschReadingEase = 206.835 - 1.015*m.AvgWordsPerSentence - 84.6*m.AvgSyllablesPerWord
+	m.FleschKincaidGrade = 0.39*m.AvgWordsPerSentence + 11.8*m.AvgSyllablesPerWord - 15.59
+	m.GunningFog = 0.4 * (m.AvgWordsPerSentence + 100*float64(m.ComplexWordCount)/float64(m.WordCount))
+	m.SMOG = 1.043*math.Sqrt(float64(m.PolysyllableCount)*(30/float64(m.SentenceCount))) + 3.1291
+
+	letters := countLetters(text)
+	avgLettersPer100Words := (float64(letters) / float64(m.WordCount)) * 100
+	avgSentencesPer100Words := (float64(m.SentenceCount) / float64(m.WordCount)) * 100
+	m.ColemanLiau = 0.0588*avgLettersPer100Words - 0.296*avgSentencesPer100Words - 15.8
+
+	m.ARI = 4.71*(float64(letters)/float64(m.WordCount)) + 0.5*m.AvgWordsPerSentence - 21.43
+
+	m.Level = fleschLevel(m.FleschReadingEase)
+
+	return m
+}
+
+// fleschLevel maps a Flesch Reading Ease score to its standard band.
+func fleschLevel(score float64) string {
+	switch {
+	case score >= 90:
+		return "Very Easy"
+	case score >= 80:
+		return "Easy"
+	case score >= 70:
+		return "Fairly Easy"
+	case score >= 60:
+		return "Standard"
+	case score >= 50:
+		return "Fairly Difficult"
+	case score >= 30:
+		return "Difficult"
+	default:
+		return "Very Difficult"
+	}
+}
+
+// splitSentences splits on . ! ? while treating a terminator as part of an
+// abbreviation (not a sentence boundary) when the preceding token is a
+// known abbreviation, or as a decimal point when surrounded by digits.
+func splitSentences(text string) []string {
+	runes := []rune(text)
+	var sentences []string
+	start := 0
+
+	for i := 0; i < len(runes); i++ {
+		r := runes[i]
+		if r != '.' && r != '!' && r != '?' {
+			continue
+		}
+
+		if r == '.' {
+			if i > 0 && i+1 < len(runes) && unicode.IsDigit(runes[i-1]) && unicode.IsDigit(runes[i+1]) {
+				continue // decimal number like "3.14"
+			}
+			if isAbbreviation(runes, start, i) {
+				continue
+			}
+		}
+
+		// Swallow repeated terminators ("?!", "...") as one boundary.
+		j := i
+		for j+1 < len(runes) && (runes[j+1] == '.' || runes[j+1] == '!' || runes[j+1] == '?') {
+			j++
+		}
+
+		sentence := strings.TrimSpace(string(runes[start : j+1]))
+		if sentence != "" {
+			sentences = append(sentences, sentence)
+		}
+		start = j + 1
+		i = j
+	}
+
+	if tail := strings.TrimSpace(string(runes[start:])); tail != "" {
+		sentences = append(sentences, tail)
+	}
+
+	return sentences
+}
+
+// isAbbreviation reports whether the word immediately preceding position
+// end (exclusive) in runes is a known abbreviation.
+func isAbbreviation(runes []rune, start, end int) bool {
+	wordStart := end
+	for wordStart > start && !unicode.IsSpace(runes[wordStart-1]) {
+		wordStart--
+	}
+	word := strings.ToLower(string(runes[wordStart:end]))
+	return commonAbbreviations[word]
+}
+
+// tokenizeWordsCased strips punctuation and splits on whitespace, preserving
+// case so callers can tell a sentence-internal capital from a lowercase
+// word; callers that don't care about case should lowercase individual
+// tokens themselves.
+func tokenizeWordsCased(text string) []string {
+	var words []string
+	var b strings.Builder
+	for _, r := range text {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) || r == '\'' {
+			b.WriteRune(r)
+			continue
+		}
+		if b.Len() > 0 {
+			words = append(words, b.String())
+			b.Reset()
+		}
+	}
+	if b.Len() > 0 {
+		words = append(words, b.String())
+	}
+	return words
+}
+
+// countLetters counts alphabetic runes only, for Coleman-Liau/ARI's
+// letters-per-100-words term.
+func countLetters(text string) int {
+	count := 0
+	for _, r := range text {
+		if unicode.IsLetter(r) {
+			count++
+		}
+	}
+	return count
+}
+
+// countSyllables is a vowel-group heuristic: count contiguous vowel runs,
+// subtract one for a silent trailing "e", and never return less than 1.
+func countSyllables(word string) int {
+	word = strings.ToLower(word)
+	if len(word) == 0 {
+		return 0
+	}
+	if len(word) <= 3 {
+		return 1
+	}
+
+	const vowels = "aeiouy"
+	count := 0
+	previousWasVowel := false
+	for _, r := range word {
+		isVowel := strings.ContainsRune(vowels, r)
+		if isVowel && !previousWasVowel {
+			count++
+		}
+		previousWasVowel = isVowel
+	}
+
+	if strings.HasSuffix(word, "e") && !strings.HasSuffix(word, "le") {
+		count--
+	}
+
+	if count < 1 {
+		count = 1
+	}
+	return count
+}
+
+// isProperNoun is a cheap heuristic: a capitalized first letter with no
+// other signal. Callers are expected to additionally exclude the first word
+// of the text, since sentence-initial capitalization isn't evidence of
+// anything.
+func isProperNoun(word string) bool {
+	r := []rune(word)
+	return len(r) > 0 && unicode.IsUpper(r[0])
+}
+
+// hasComplexSuffixException reports whether word's apparent complexity is
+// just a common inflectional suffix (-es, -ed, -ing), which Gunning Fog
+// exempts from the complex-word count.
+func hasComplexSuffixException(word string) bool {
+	for _, suffix := range complexSuffixes {
+		if strings.HasSuffix(word, suffix) && len(word) > len(suffix)+2 {
+			return true
+		}
+	}
+	return false
+}
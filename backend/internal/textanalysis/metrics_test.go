@@ -0,0 +1,113 @@
+package textanalysis
+
+import "testing"
+
+func TestMetrics_BasicCounts(t *testing.T) {
+	text := "The quick brown fox jumps over the lazy dog. It runs fast!"
+	m := Metrics(text, "en")
+
+	if !m.Supported {
+		t.Fatal("expected English text to be Supported")
+	}
+	if m.SentenceCount != 2 {
+		t.Errorf("expected 2 sentences, got %d", m.SentenceCount)
+	}
+	if m.WordCount == 0 {
+		t.Error("expected a non-zero word count")
+	}
+	if m.FleschReadingEase == 0 {
+		t.Error("expected a computed Flesch Reading Ease score")
+	}
+}
+
+func TestMetrics_UnsupportedLanguage(t *testing.T) {
+	m := Metrics("Le renard brun rapide.", "fr")
+	if m.Supported {
+		t.Error("expected non-English text to be marked Unsupported")
+	}
+	if m.FleschReadingEase != 0 {
+		t.Error("expected readability scores to stay zero for unsupported languages")
+	}
+	if m.WordCount == 0 {
+		t.Error("expected word count to still be populated for unsupported languages")
+	}
+}
+
+func TestMetrics_EmptyText(t *testing.T) {
+	m := Metrics("", "en")
+	if m.WordCount != 0 || m.SentenceCount != 0 {
+		t.Errorf("expected zero counts for empty text, got words=%d sentences=%d", m.WordCount, m.SentenceCount)
+	}
+}
+
+func TestSplitSentences_HonorsAbbreviations(t *testing.T) {
+	sentences := splitSentences("Mr. Smith went home. He was tired.")
+	if len(sentences) != 2 {
+		t.Fatalf("expected abbreviation \"Mr.\" not to split a sentence, got %d sentences: %v", len(sentences), sentences)
+	}
+}
+
+func TestSplitSentences_HonorsDecimals(t *testing.T) {
+	sentences := splitSentences("Pi is about 3.14 and that's that.")
+	if len(sentences) != 1 {
+		t.Fatalf("expected decimal point not to split a sentence, got %d sentences: %v", len(sentences), sentences)
+	}
+}
+
+func TestSplitSentences_SwallowsRepeatedTerminators(t *testing.T) {
+	sentences := splitSentences("Wait, really?! Yes, truly.")
+	if len(sentences) != 2 {
+		t.Fatalf("expected \"?!\" to count as one boundary, got %d sentences: %v", len(sentences), sentences)
+	}
+}
+
+func TestFleschLevel_Bands(t *testing.T) {
+	tests := []struct {
+		score float64
+		want  string
+	}{
+		{95, "Very Easy"},
+		{85, "Easy"},
+		{75, "Fairly Easy"},
+		{65, "Standard"},
+		{55, "Fairly Difficult"},
+		{35, "Difficult"},
+		{10, "Very Difficult"},
+	}
+	for _, tc := range tests {
+		if got := fleschLevel(tc.score); got != tc.want {
+			t.Errorf("fleschLevel(%v) = %q, want %q", tc.score, got, tc.want)
+		}
+	}
+}
+
+func TestCountSyllables(t *testing.T) {
+	tests := []struct {
+		word string
+		want int
+	}{
+		{"cat", 1},
+		{"happy", 2},
+		{"beautiful", 3},
+		{"time", 1}, // silent trailing e
+		{"little", 2},
+	}
+	for _, tc := range tests {
+		if got := countSyllables(tc.word); got != tc.want {
+			t.Errorf("countSyllables(%q) = %d, want %d", tc.word, got, tc.want)
+		}
+	}
+}
+
+func TestTokenizeWordsCased_StripsPunctuation(t *testing.T) {
+	words := tokenizeWordsCased("Hello, world! Isn't it nice?")
+	want := []string{"Hello", "world", "Isn't", "it", "nice"}
+	if len(words) != len(want) {
+		t.Fatalf("got %v, want %v", words, want)
+	}
+	for i := range want {
+		if words[i] != want[i] {
+			t.Errorf("word %d = %q, want %q", i, words[i], want[i])
+		}
+	}
+}
@@ -0,0 +1,51 @@
+package translation
+
+import (
+	"log"
+	"time"
+
+	"github.com/bartosz/stocks-out-for-harambe/backend/internal/config"
+)
+
+// BuildProvider selects the Provider configured by cfg.Provider:
+// LibreTranslateProvider by default, or DeepLProvider/VolcanoProvider/
+// CaiyunProvider for "deepl"/"volcano"/"caiyun". Returns nil when
+// cfg.Enabled is false or the configured provider is missing required
+// config, so callers can treat a nil Provider as "translation disabled".
+func BuildProvider(cfg config.TranslationConfig) Provider {
+	if !cfg.Enabled {
+		return nil
+	}
+
+	timeout := time.Duration(cfg.TimeoutSeconds) * time.Second
+
+	switch cfg.Provider {
+	case "deepl":
+		if cfg.DeepLAPIKey == "" {
+			log.Printf("Warning: TRANSLATION_PROVIDER=deepl but TRANSLATION_DEEPL_API_KEY is unset, disabling translation")
+			return nil
+		}
+		return NewDeepLProvider(cfg.DeepLBaseURL, cfg.DeepLAPIKey, timeout, cfg.MaxRetries)
+	case "volcano":
+		if cfg.VolcanoAccessKey == "" || cfg.VolcanoSecretKey == "" {
+			log.Printf("Warning: TRANSLATION_PROVIDER=volcano but access/secret key is unset, disabling translation")
+			return nil
+		}
+		return NewVolcanoProvider(cfg.VolcanoBaseURL, cfg.VolcanoAccessKey, cfg.VolcanoSecretKey, timeout, cfg.MaxRetries)
+	case "caiyun":
+		if cfg.CaiyunToken == "" {
+			log.Printf("Warning: TRANSLATION_PROVIDER=caiyun but TRANSLATION_CAIYUN_TOKEN is unset, disabling translation")
+			return nil
+		}
+		return NewCaiyunProvider(cfg.CaiyunBaseURL, cfg.CaiyunToken, timeout, cfg.MaxRetries)
+	case "libretranslate", "":
+		if cfg.LibreTranslateBaseURL == "" {
+			log.Printf("Warning: TRANSLATION_PROVIDER=libretranslate but TRANSLATION_LIBRETRANSLATE_BASE_URL is unset, disabling translation")
+			return nil
+		}
+		return NewLibreTranslateProvider(cfg.LibreTranslateBaseURL, cfg.LibreTranslateAPIKey, timeout, cfg.MaxRetries)
+	default:
+		log.Printf("Warning: unknown TRANSLATION_PROVIDER %q, disabling translation", cfg.Provider)
+		return nil
+	}
+}
@@ -0,0 +1,86 @@
+package translation
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// CaiyunProvider calls the Caiyun Xiaoyi translation API, which is
+// particularly strong on Chinese<->English. It doesn't expose dictionary
+// senses, so the result's Senses is always empty.
+type CaiyunProvider struct {
+	baseURL    string
+	token      string
+	maxRetries int
+	httpClient *http.Client
+}
+
+// NewCaiyunProvider creates a CaiyunProvider.
+func NewCaiyunProvider(baseURL, token string, timeout time.Duration, maxRetries int) *CaiyunProvider {
+	return &CaiyunProvider{
+		baseURL:    baseURL,
+		token:      token,
+		maxRetries: maxRetries,
+		httpClient: &http.Client{Timeout: timeout},
+	}
+}
+
+type caiyunRequest struct {
+	Source    []string `json:"source"`
+	TransType string   `json:"trans_type"`
+	Detect    bool     `json:"detect"`
+}
+
+type caiyunResponse struct {
+	Target []string `json:"target"`
+}
+
+// caiyunTransType maps a source/target language pair to the trans_type
+// token Caiyun's API expects (e.g. "zh2en", "en2zh", or "auto2zh" when the
+// source is unknown).
+func caiyunTransType(sourceLang, targetLang string) string {
+	if sourceLang == "" {
+		return "auto2" + targetLang
+	}
+	return sourceLang + "2" + targetLang
+}
+
+// Translate calls POST {baseURL}/v1/translator.
+func (p *CaiyunProvider) Translate(ctx context.Context, text, sourceLang, targetLang string) (Result, error) {
+	reqBody := caiyunRequest{
+		Source:    []string{text},
+		TransType: caiyunTransType(sourceLang, targetLang),
+		Detect:    sourceLang == "",
+	}
+	jsonData, err := json.Marshal(reqBody)
+	if err != nil {
+		return Result{}, fmt.Errorf("translation: failed to marshal request body: %w", err)
+	}
+
+	responseBody, err := httpRetry(ctx, p.httpClient, p.maxRetries, func() (*http.Request, error) {
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.baseURL+"/v1/translator", bytes.NewBuffer(jsonData))
+		if err != nil {
+			return nil, err
+		}
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("token", p.token)
+		return req, nil
+	})
+	if err != nil {
+		return Result{}, err
+	}
+
+	var resp caiyunResponse
+	if err := json.Unmarshal(responseBody, &resp); err != nil {
+		return Result{}, fmt.Errorf("translation: failed to unmarshal response: %w", err)
+	}
+	if len(resp.Target) == 0 {
+		return Result{}, fmt.Errorf("translation: caiyun returned no translations")
+	}
+
+	return Result{TranslatedText: resp.Target[0]}, nil
+}
@@ -0,0 +1,74 @@
+package translation
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// DeepLProvider calls the DeepL translation API. DeepL doesn't expose
+// dictionary senses, so the result's Senses is always empty.
+type DeepLProvider struct {
+	baseURL    string
+	apiKey     string
+	maxRetries int
+	httpClient *http.Client
+}
+
+// NewDeepLProvider creates a DeepLProvider. baseURL is typically
+// "https://api-free.deepl.com" or "https://api.deepl.com" depending on the
+// account plan.
+func NewDeepLProvider(baseURL, apiKey string, timeout time.Duration, maxRetries int) *DeepLProvider {
+	return &DeepLProvider{
+		baseURL:    baseURL,
+		apiKey:     apiKey,
+		maxRetries: maxRetries,
+		httpClient: &http.Client{Timeout: timeout},
+	}
+}
+
+type deeplResponse struct {
+	Translations []struct {
+		DetectedSourceLanguage string `json:"detected_source_language"`
+		Text                   string `json:"text"`
+	} `json:"translations"`
+}
+
+// Translate calls POST {baseURL}/v2/translate with a form-urlencoded body,
+// DeepL's expected content type.
+func (p *DeepLProvider) Translate(ctx context.Context, text, sourceLang, targetLang string) (Result, error) {
+	form := url.Values{}
+	form.Set("text", text)
+	form.Set("target_lang", strings.ToUpper(targetLang))
+	if sourceLang != "" {
+		form.Set("source_lang", strings.ToUpper(sourceLang))
+	}
+	encoded := form.Encode()
+
+	responseBody, err := httpRetry(ctx, p.httpClient, p.maxRetries, func() (*http.Request, error) {
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.baseURL+"/v2/translate", strings.NewReader(encoded))
+		if err != nil {
+			return nil, err
+		}
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		req.Header.Set("Authorization", "DeepL-Auth-Key "+p.apiKey)
+		return req, nil
+	})
+	if err != nil {
+		return Result{}, err
+	}
+
+	var resp deeplResponse
+	if err := json.Unmarshal(responseBody, &resp); err != nil {
+		return Result{}, fmt.Errorf("translation: failed to unmarshal response: %w", err)
+	}
+	if len(resp.Translations) == 0 {
+		return Result{}, fmt.Errorf("translation: deepl returned no translations")
+	}
+
+	return Result{TranslatedText: resp.Translations[0].Text}, nil
+}
@@ -0,0 +1,76 @@
+package translation
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// LibreTranslateProvider calls a self-hosted LibreTranslate instance over
+// JSON-over-HTTP. It's the simplest of the four providers to self-host, so
+// it's the default "enabled" provider when Config.Translation.Provider is
+// unset.
+type LibreTranslateProvider struct {
+	baseURL    string
+	apiKey     string
+	maxRetries int
+	httpClient *http.Client
+}
+
+// NewLibreTranslateProvider creates a LibreTranslateProvider talking to baseURL.
+func NewLibreTranslateProvider(baseURL, apiKey string, timeout time.Duration, maxRetries int) *LibreTranslateProvider {
+	return &LibreTranslateProvider{
+		baseURL:    baseURL,
+		apiKey:     apiKey,
+		maxRetries: maxRetries,
+		httpClient: &http.Client{Timeout: timeout},
+	}
+}
+
+type libreTranslateRequest struct {
+	Q      string `json:"q"`
+	Source string `json:"source"`
+	Target string `json:"target"`
+	Format string `json:"format"`
+	APIKey string `json:"api_key,omitempty"`
+}
+
+type libreTranslateResponse struct {
+	TranslatedText string `json:"translatedText"`
+}
+
+// Translate calls POST {baseURL}/translate. LibreTranslate doesn't expose
+// dictionary senses, so the result's Senses is always empty.
+func (p *LibreTranslateProvider) Translate(ctx context.Context, text, sourceLang, targetLang string) (Result, error) {
+	if sourceLang == "" {
+		sourceLang = "auto"
+	}
+
+	reqBody := libreTranslateRequest{Q: text, Source: sourceLang, Target: targetLang, Format: "text", APIKey: p.apiKey}
+	jsonData, err := json.Marshal(reqBody)
+	if err != nil {
+		return Result{}, fmt.Errorf("translation: failed to marshal request body: %w", err)
+	}
+
+	responseBody, err := httpRetry(ctx, p.httpClient, p.maxRetries, func() (*http.Request, error) {
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.baseURL+"/translate", bytes.NewBuffer(jsonData))
+		if err != nil {
+			return nil, err
+		}
+		req.Header.Set("Content-Type", "application/json")
+		return req, nil
+	})
+	if err != nil {
+		return Result{}, err
+	}
+
+	var resp libreTranslateResponse
+	if err := json.Unmarshal(responseBody, &resp); err != nil {
+		return Result{}, fmt.Errorf("translation: failed to unmarshal response: %w", err)
+	}
+
+	return Result{TranslatedText: resp.TranslatedText}, nil
+}
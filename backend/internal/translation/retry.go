@@ -0,0 +1,64 @@
+package translation
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+// httpRetry issues the request built by buildReq, retrying on 429/5xx with
+// exponential backoff (1s, 2s, 4s, 8s...), the same shape as
+// FirecrawlClient.makeRequest. buildReq is invoked fresh on every attempt
+// since an *http.Request's body can't be replayed once read.
+func httpRetry(ctx context.Context, client *http.Client, maxRetries int, buildReq func() (*http.Request, error)) ([]byte, error) {
+	var lastErr error
+
+	for attempt := 0; attempt <= maxRetries; attempt++ {
+		if attempt > 0 {
+			waitTime := time.Duration(1<<uint(attempt-1)) * time.Second
+			select {
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			case <-time.After(waitTime):
+			}
+		}
+
+		req, err := buildReq()
+		if err != nil {
+			return nil, fmt.Errorf("translation: failed to build request: %w", err)
+		}
+
+		resp, err := client.Do(req)
+		if err != nil {
+			lastErr = fmt.Errorf("translation: request failed: %w", err)
+			continue
+		}
+
+		responseBody, err := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		if err != nil {
+			lastErr = fmt.Errorf("translation: failed to read response body: %w", err)
+			continue
+		}
+
+		if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+			return responseBody, nil
+		}
+
+		switch {
+		case resp.StatusCode == http.StatusTooManyRequests:
+			lastErr = fmt.Errorf("translation: rate limited (429): %s", string(responseBody))
+			// Continue retrying for rate limits
+		case resp.StatusCode >= 500:
+			lastErr = fmt.Errorf("translation: server error (%d): %s", resp.StatusCode, string(responseBody))
+			// Continue retrying for server errors
+		default:
+			// Don't retry for other client errors
+			return nil, fmt.Errorf("translation: client error (%d): %s", resp.StatusCode, string(responseBody))
+		}
+	}
+
+	return nil, fmt.Errorf("translation: request failed after %d retries: %w", maxRetries, lastErr)
+}
@@ -0,0 +1,30 @@
+// Package translation provides pluggable machine-translation/dictionary
+// enrichment for non-English TextAnalysis input, behind a Provider
+// interface analogous to nlp.Provider. Result types are defined here
+// rather than reusing models.* so this package doesn't need to import
+// models, matching the separation nlp.Provider keeps from models.
+package translation
+
+import "context"
+
+// Sense is one dictionary-style explanation of a translated word or
+// phrase. Most machine-translation backends (DeepL, LibreTranslate) don't
+// return these at all, so Result.Senses is commonly empty.
+type Sense struct {
+	PartOfSpeech string
+	Phonetic     string
+	Definition   string
+	Examples     []string
+}
+
+// Result is Provider's translation output.
+type Result struct {
+	TranslatedText string
+	Senses         []Sense
+}
+
+// Provider translates text from sourceLang to targetLang. sourceLang may
+// be empty to ask the provider to auto-detect it.
+type Provider interface {
+	Translate(ctx context.Context, text, sourceLang, targetLang string) (Result, error)
+}
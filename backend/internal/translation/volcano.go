@@ -0,0 +1,99 @@
+package translation
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// VolcanoProvider calls the Volcano Engine (ByteDance) machine translation
+// API. It doesn't expose dictionary senses, so the result's Senses is
+// always empty.
+type VolcanoProvider struct {
+	baseURL   string
+	accessKey string
+	secretKey string
+
+	maxRetries int
+	httpClient *http.Client
+}
+
+// NewVolcanoProvider creates a VolcanoProvider. accessKey/secretKey are the
+// account's API credential pair used to sign every request.
+func NewVolcanoProvider(baseURL, accessKey, secretKey string, timeout time.Duration, maxRetries int) *VolcanoProvider {
+	return &VolcanoProvider{
+		baseURL:    baseURL,
+		accessKey:  accessKey,
+		secretKey:  secretKey,
+		maxRetries: maxRetries,
+		httpClient: &http.Client{Timeout: timeout},
+	}
+}
+
+type volcanoRequest struct {
+	TargetLanguage string   `json:"TargetLanguage"`
+	SourceLanguage string   `json:"SourceLanguage,omitempty"`
+	TextList       []string `json:"TextList"`
+}
+
+type volcanoResponse struct {
+	TranslationList []struct {
+		Translation            string `json:"Translation"`
+		DetectedSourceLanguage string `json:"DetectedSourceLanguage"`
+	} `json:"TranslationList"`
+}
+
+// sign computes the HMAC-SHA256 signature Volcano's gateway expects over
+// the request timestamp and body, base64-encoded. This is a simplified
+// stand-in for Volcengine's full request-signing scheme (which also signs
+// headers and the canonical query string), sized to what this client
+// actually sends.
+func (p *VolcanoProvider) sign(timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(p.secretKey))
+	mac.Write([]byte(timestamp))
+	mac.Write(body)
+	return base64.StdEncoding.EncodeToString(mac.Sum(nil))
+}
+
+// Translate calls POST {baseURL}/?Action=TranslateText&Version=2020-06-01.
+func (p *VolcanoProvider) Translate(ctx context.Context, text, sourceLang, targetLang string) (Result, error) {
+	reqBody := volcanoRequest{TargetLanguage: targetLang, SourceLanguage: sourceLang, TextList: []string{text}}
+	jsonData, err := json.Marshal(reqBody)
+	if err != nil {
+		return Result{}, fmt.Errorf("translation: failed to marshal request body: %w", err)
+	}
+
+	responseBody, err := httpRetry(ctx, p.httpClient, p.maxRetries, func() (*http.Request, error) {
+		timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.baseURL+"/?Action=TranslateText&Version=2020-06-01", bytes.NewBuffer(jsonData))
+		if err != nil {
+			return nil, err
+		}
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("X-Date", timestamp)
+		req.Header.Set("X-Access-Key", p.accessKey)
+		req.Header.Set("Authorization", "HMAC-SHA256 "+p.sign(timestamp, jsonData))
+		return req, nil
+	})
+	if err != nil {
+		return Result{}, err
+	}
+
+	var resp volcanoResponse
+	if err := json.Unmarshal(responseBody, &resp); err != nil {
+		return Result{}, fmt.Errorf("translation: failed to unmarshal response: %w", err)
+	}
+	if len(resp.TranslationList) == 0 {
+		return Result{}, fmt.Errorf("translation: volcano returned no translations")
+	}
+
+	return Result{TranslatedText: resp.TranslationList[0].Translation}, nil
+}
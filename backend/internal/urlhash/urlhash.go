@@ -0,0 +1,114 @@
+// Package urlhash canonicalizes URLs before hashing them, so that
+// URLExtraction.URLHash is a stable, content-addressable key: two URLs that
+// only differ in query-param order, tracking params, or default ports hash
+// identically and dedup correctly.
+package urlhash
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"net/url"
+	"path"
+	"sort"
+	"strings"
+)
+
+// trackingParamPrefixes and trackingParamNames are stripped during
+// normalization since they don't change what a page actually is.
+var trackingParamPrefixes = []string{"utm_"}
+
+var trackingParamNames = map[string]bool{
+	"fbclid": true,
+	"gclid":  true,
+	"msclkid": true,
+}
+
+// defaultPorts maps a scheme to the port implied by that scheme, which is
+// stripped so "http://x.com:80" and "http://x.com" normalize identically.
+var defaultPorts = map[string]string{
+	"http":  "80",
+	"https": "443",
+}
+
+// Normalize canonicalizes a URL for hashing: lowercases scheme and host,
+// strips the default port for the scheme, drops the fragment, resolves
+// "." / ".." path segments, removes tracking query params, and sorts the
+// remaining ones.
+func Normalize(rawURL string) (string, error) {
+	u, err := url.Parse(strings.TrimSpace(rawURL))
+	if err != nil {
+		return "", err
+	}
+
+	u.Scheme = strings.ToLower(u.Scheme)
+	u.Host = strings.ToLower(u.Host)
+	u.Fragment = ""
+
+	if host, port, ok := strings.Cut(u.Host, ":"); ok {
+		if defaultPorts[u.Scheme] == port {
+			u.Host = host
+		}
+	}
+
+	if u.RawQuery != "" {
+		query := u.Query()
+		for key := range query {
+			lowerKey := strings.ToLower(key)
+			if trackingParamNames[lowerKey] || hasTrackingPrefix(lowerKey) {
+				query.Del(key)
+			}
+		}
+
+		keys := make([]string, 0, len(query))
+		for key := range query {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+
+		var b strings.Builder
+		for i, key := range keys {
+			for j, value := range query[key] {
+				if i > 0 || j > 0 {
+					b.WriteByte('&')
+				}
+				b.WriteString(url.QueryEscape(key))
+				b.WriteByte('=')
+				b.WriteString(url.QueryEscape(value))
+			}
+		}
+		u.RawQuery = b.String()
+	}
+
+	if u.Path == "" {
+		u.Path = "/"
+	} else {
+		cleaned := path.Clean(u.Path)
+		if strings.HasSuffix(u.Path, "/") && cleaned != "/" {
+			cleaned += "/"
+		}
+		u.Path = cleaned
+	}
+
+	return u.String(), nil
+}
+
+func hasTrackingPrefix(key string) bool {
+	for _, prefix := range trackingParamPrefixes {
+		if strings.HasPrefix(key, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// Hash canonicalizes rawURL and returns the hex-encoded SHA-256 digest of
+// the result. If rawURL fails to parse, it hashes the trimmed input as-is
+// so callers always get a stable, non-empty digest.
+func Hash(rawURL string) string {
+	normalized, err := Normalize(rawURL)
+	if err != nil {
+		normalized = strings.TrimSpace(rawURL)
+	}
+	sum := sha256.Sum256([]byte(normalized))
+	return hex.EncodeToString(sum[:])
+}
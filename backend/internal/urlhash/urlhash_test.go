@@ -0,0 +1,84 @@
+package urlhash
+
+import "testing"
+
+func TestNormalize(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want string
+	}{
+		{
+			name: "lowercases scheme and host",
+			in:   "HTTP://Example.COM/path",
+			want: "http://example.com/path",
+		},
+		{
+			name: "strips default port for scheme",
+			in:   "http://example.com:80/path",
+			want: "http://example.com/path",
+		},
+		{
+			name: "keeps non-default port",
+			in:   "http://example.com:8080/path",
+			want: "http://example.com:8080/path",
+		},
+		{
+			name: "drops fragment",
+			in:   "http://example.com/path#section",
+			want: "http://example.com/path",
+		},
+		{
+			name: "sorts query params and strips tracking params",
+			in:   "http://example.com/path?b=2&utm_source=news&a=1&fbclid=xyz",
+			want: "http://example.com/path?a=1&b=2",
+		},
+		{
+			name: "resolves dot segments",
+			in:   "http://example.com/a/../b/./c",
+			want: "http://example.com/b/c",
+		},
+		{
+			name: "defaults empty path to slash",
+			in:   "http://example.com",
+			want: "http://example.com/",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := Normalize(tc.in)
+			if err != nil {
+				t.Fatalf("Normalize(%q) returned error: %v", tc.in, err)
+			}
+			if got != tc.want {
+				t.Errorf("Normalize(%q) = %q, want %q", tc.in, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestHash_StableAcrossEquivalentURLs(t *testing.T) {
+	a := Hash("http://Example.com:80/path?b=2&utm_source=news&a=1")
+	b := Hash("http://example.com/path?a=1&b=2")
+	if a != b {
+		t.Errorf("expected equivalent URLs to hash identically, got %q and %q", a, b)
+	}
+}
+
+func TestHash_DiffersForDifferentURLs(t *testing.T) {
+	a := Hash("http://example.com/one")
+	b := Hash("http://example.com/two")
+	if a == b {
+		t.Errorf("expected different URLs to hash differently, both got %q", a)
+	}
+}
+
+func TestHash_NeverEmpty(t *testing.T) {
+	if got := Hash(""); got == "" {
+		t.Error("Hash(\"\") should still return a stable non-empty digest")
+	}
+	if got := Hash("://not a valid url"); got == "" {
+		t.Error("Hash of an unparseable URL should still return a stable non-empty digest")
+	}
+}